@@ -25,23 +25,57 @@ var alertCalloutsIconsHybrid string
 //go:embed assets/alertcallouts-obsidian.icons
 var alertCalloutsIconsObsidian string
 
+//go:embed assets/alertcallouts-emoji.icons
+var alertCalloutsIconsEmoji string
+
 var _ = alertCalloutsIconsGFMStrict
 var _ = alertCalloutsIconsHybrid
 var _ = alertCalloutsIconsObsidian
+var _ = alertCalloutsIconsEmoji
 
 // Config holds all configuration options for alert callouts rendering.
 // This struct is passed to renderer constructors to avoid long parameter lists
 // and make it easy to add new options without breaking function signatures.
 type Config struct {
 	Icons               map[string]string // Icon map for different alert types
+	Aliases             map[string]string // Alias to primary kind map for the icon set (nil when unknown)
 	FoldingEnabled      bool              // Whether folding functionality is enabled
 	CustomAlertsEnabled bool              // Whether custom alert types are allowed
 	DefaultIcons        int               // Which default icon set to use (constants.ICONS_*)
+	IconSet             string            // Name of the icon set in use, written as the iconset-<name> class (empty for none)
 	AllowNOICON         bool              // Whether to allow NOICON alert types (example of new option)
+	Colors              map[string]string // Hex accent color per kind for non-HTML output (built-in map when nil)
+	Emoji               map[string]string // Emoji per kind for non-HTML output (built-in map when nil)
+	Titles              map[string]string // Default display title per kind (the title-cased kind when missing)
+	Labels              map[string]string // Accessible label per kind for emoji icons (the title when missing)
+	FoldStates          map[string]string // Default fold state per kind for callouts without '+' or '-' (needs folding)
+	GFMKinds            map[string]string // GFM kind per kind, used by outputs that only map the five GFM kinds
+	IconMode            int               // How headers include their icons (constants.ICON_MODE_*)
+	SpriteURL           string            // URL of an external icon sprite (empty to append the sprite to each document)
+	Extends             map[string]string // Parent kind per kind; callouts also get the CSS class of each parent
+	AllowedKinds        []string          // When not empty, the only kinds the parser accepts (icons or not)
+	DeniedKinds         []string          // Kinds the parser never accepts, even with custom alerts enabled
+	CanonicalKinds      bool              // Whether aliases render as their primary kind in classes and data-callout
+	SVGPolicy           *SVGPolicy        // Sanitizer for icons that are not built in (nil writes icons verbatim)
+	SVGProcessor        *SVGProcessor     // Processor applied to every icon after sanitizing (nil for none)
 }
 
+// IconMode selects how the callout headers include their icons.
+type IconMode int
+
+const (
+	// IconsInline writes the full icon markup into every callout header (the default).
+	IconsInline IconMode = constants.ICON_MODE_INLINE
+	// IconsSprite writes `<svg><use href="#callout-icon-note"/></svg>` references to the symbols of an SVG sprite.
+	IconsSprite IconMode = constants.ICON_MODE_SPRITE
+	// IconsCSS writes an empty `<span class="callout-icon"></span>` that the stylesheet from IconStylesheet draws.
+	IconsCSS IconMode = constants.ICON_MODE_CSS
+)
+
 type alertCalloutsOptions struct {
-	config Config
+	config      Config
+	composition iconComposition // Applied by NewAlertCallouts after all other options
+	colorKinds  map[string]bool // Kinds whose color in config.Colors was set explicitly, not built in
 }
 
 // Option is a functional option for configuring alertCalloutsOptions.
@@ -51,16 +85,40 @@ type Option func(*alertCalloutsOptions)
 func WithIcons(icons map[string]string) Option {
 	return func(opts *alertCalloutsOptions) {
 		opts.config.Icons = icons
+		opts.config.Aliases = nil
+		opts.config.Titles = nil
+		opts.config.Labels = nil
+		opts.config.FoldStates = nil
+		opts.config.GFMKinds = nil
+	}
+}
+
+// WithIconAliases sets which kinds of the icon set are aliases, mapping each alias to its primary kind.
+// The built-in icon sets set this automatically; use it with WithIcons and CreateIconAliasesMap
+// so exporters can report the canonical kind of custom icon sets.
+func WithIconAliases(aliases map[string]string) Option {
+	return func(opts *alertCalloutsOptions) {
+		opts.config.Aliases = aliases
+	}
+}
+
+// WithCanonicalKinds sets whether aliases render as their primary kind. When enabled, `> [!info]`
+// in the Hybrid icon set renders with class `callout-note` and `data-callout="note"`, and the
+// spelling from the markdown is kept in `data-callout-alias="info"`, so stylesheets only need
+// rules for the primary kinds. The header still shows the alias as its default title.
+func WithCanonicalKinds(enable bool) Option {
+	return func(opts *alertCalloutsOptions) {
+		opts.config.CanonicalKinds = enable
 	}
 }
 
 // WithIcon adds a single icon to the icons map for alert callouts.
+// The current map is copied, so a map passed to WithIcons is never modified.
 func WithIcon(kind, icon string) Option {
 	return func(opts *alertCalloutsOptions) {
-		if opts.config.Icons == nil {
-			opts.config.Icons = make(map[string]string)
-		}
-		opts.config.Icons[kind] = icon
+		icons := copyMap(opts.config.Icons)
+		icons[kind] = icon
+		opts.config.Icons = icons
 	}
 }
 
@@ -72,8 +130,9 @@ func UseGFMIcons() Option {
 
 func UseGFMStrictIcons() Option {
 	return func(opts *alertCalloutsOptions) {
-		opts.config.Icons = utils.CreateIconsMap(alertCalloutsIconsGFMStrict)
+		WithIconSet(utils.ParseIconSet(alertCalloutsIconsGFMStrict))(opts)
 		opts.config.DefaultIcons = constants.ICONS_GFM
+		opts.config.IconSet = "gfm"
 		opts.config.FoldingEnabled = false
 		opts.config.CustomAlertsEnabled = false
 		opts.config.AllowNOICON = false
@@ -96,8 +155,9 @@ func UseGFMPlusIcons() Option {
 // UseGFMPlusIcons sets the icon map to the GFM Plus icon set (essentially a melding of GFM and Obsidian).
 func UseHybridIcons() Option {
 	return func(opts *alertCalloutsOptions) {
-		opts.config.Icons = utils.CreateIconsMap(alertCalloutsIconsHybrid)
+		WithIconSet(utils.ParseIconSet(alertCalloutsIconsHybrid))(opts)
 		opts.config.DefaultIcons = constants.ICONS_HYBRID
+		opts.config.IconSet = "hybrid"
 		opts.config.FoldingEnabled = true
 		opts.config.CustomAlertsEnabled = true
 		opts.config.AllowNOICON = true
@@ -107,14 +167,30 @@ func UseHybridIcons() Option {
 // UseObsidianIcons sets the icon map to the Obsidian-style icon set.
 func UseObsidianIcons() Option {
 	return func(opts *alertCalloutsOptions) {
-		opts.config.Icons = utils.CreateIconsMap(alertCalloutsIconsObsidian)
+		WithIconSet(utils.ParseIconSet(alertCalloutsIconsObsidian))(opts)
 		opts.config.DefaultIcons = constants.ICONS_OBSIDIAN
+		opts.config.IconSet = "obsidian"
 		opts.config.FoldingEnabled = true
 		opts.config.CustomAlertsEnabled = true
 		opts.config.AllowNOICON = false
 	}
 }
 
+// UseEmojiIcons sets the icon map to the emoji icon set, which has a Unicode emoji for every kind
+// and alias of the Hybrid and Obsidian icon sets. The header writes each emoji as
+// `<span class="callout-icon-emoji" role="img" aria-label="Tip">💡</span>`, which suits plain,
+// feed-friendly and lightweight output.
+func UseEmojiIcons() Option {
+	return func(opts *alertCalloutsOptions) {
+		WithIconSet(utils.ParseIconSet(alertCalloutsIconsEmoji))(opts)
+		opts.config.DefaultIcons = constants.ICONS_EMOJI
+		opts.config.IconSet = "emoji"
+		opts.config.FoldingEnabled = true
+		opts.config.CustomAlertsEnabled = true
+		opts.config.AllowNOICON = true
+	}
+}
+
 // WithFolding sets the folding functionality for alert callouts.
 func WithFolding(enable bool) Option {
 	return func(opts *alertCalloutsOptions) {
@@ -139,6 +215,113 @@ func WithAllowNOICON(enable bool) Option {
 	}
 }
 
+// WithColors sets the accent color for each alert kind as a six-digit hex value (e.g. "0969DA").
+// The colors are used by the non-HTML renderers, such as the borders of Jira panels.
+// Only the primary kinds need an entry: aliases are resolved to their primary kind through the icon set.
+func WithColors(colors map[string]string) Option {
+	return func(opts *alertCalloutsOptions) {
+		opts.config.Colors = colors
+		opts.colorKinds = nil
+		opts.addColorKinds(colors)
+	}
+}
+
+// addColorKinds records the kinds of colors as set explicitly, so the stylesheet can tell them
+// from the built-in colors merged into the same map.
+func (e *alertCalloutsOptions) addColorKinds(colors map[string]string) {
+	kinds := make(map[string]bool, len(e.colorKinds)+len(colors))
+	for kind := range e.colorKinds {
+		kinds[kind] = true
+	}
+	for kind := range colors {
+		kinds[kind] = true
+	}
+	e.colorKinds = kinds
+}
+
+// WithEmoji sets the emoji for each alert kind.
+// The emoji are used by the non-HTML renderers, such as the label of Slack messages.
+// Only the primary kinds need an entry: aliases are resolved to their primary kind through the icon set.
+func WithEmoji(emoji map[string]string) Option {
+	return func(opts *alertCalloutsOptions) {
+		opts.config.Emoji = emoji
+	}
+}
+
+// WithIconSet sets the icons, aliases, default titles, labels, fold states and GFM kinds of a
+// parsed icon set, replacing any existing icons. Accent colors defined in the icon set are merged
+// into the colors used by the non-HTML renderers.
+func WithIconSet(set *IconSet) Option {
+	return func(opts *alertCalloutsOptions) {
+		opts.config.Icons = set.Icons()
+		opts.config.Aliases = set.Aliases()
+		opts.config.Titles = set.Titles()
+		opts.config.Labels = set.Labels()
+		opts.config.FoldStates = set.FoldStates()
+		opts.config.GFMKinds = set.GFMKinds()
+		if colors := set.Colors(); len(colors) > 0 {
+			merged := make(map[string]string)
+			base := opts.config.Colors
+			if base == nil {
+				base = constants.KIND_COLOR
+			}
+			for kind, color := range base {
+				merged[kind] = color
+			}
+			for kind, color := range colors {
+				merged[kind] = color
+			}
+			opts.config.Colors = merged
+			opts.addColorKinds(colors)
+		}
+	}
+}
+
+// WithIconMode sets how the callout headers include their icons.
+func WithIconMode(mode IconMode) Option {
+	return func(opts *alertCalloutsOptions) {
+		opts.config.IconMode = int(mode)
+	}
+}
+
+// IconSet is the parsed form of an icon definition file, including the optional per-kind fields.
+type IconSet = utils.IconSet
+
+// IconDefinition holds everything an icon set says about a single kind.
+type IconDefinition = utils.IconDefinition
+
+// IconGroup is a section of an icon file: the kinds defined after a block of comment lines.
+// IconSet.Groups returns them, next to IconSet.Primaries, IconSet.AliasesOf and IconSet.PrimaryOf.
+type IconGroup = utils.IconGroup
+
+// LoadIconSet parses icon data in the .icons format into an IconSet for use with WithIconSet.
+// Besides `key|svg` and `alias->primary` lines, the format accepts optional `kind.field=value`
+// lines for the fields color, title, label, fold and gfm (see docs/ICONMAPS.md).
+func LoadIconSet(iconData string) *IconSet {
+	return utils.ParseIconSet(iconData)
+}
+
+// Diagnostic reports a line of icon data that was skipped or needs attention, with its position.
+type Diagnostic = utils.Diagnostic
+
+// Severity tells how serious a Diagnostic is.
+type Severity = utils.Severity
+
+const (
+	// SeverityWarning marks a definition that was skipped or lost to another definition.
+	SeverityWarning = utils.SeverityWarning
+	// SeverityInfo marks an expected override, such as a later icon set replacing a kind.
+	SeverityInfo = utils.SeverityInfo
+)
+
+// MergeIconSets combines icon sets into one, as if their files were read one after the other:
+// a kind defined in a later set replaces the earlier definition, and aliases may point at kinds
+// of any of the sets. The diagnostics report every override, collision and alias that cannot be
+// resolved in the combined set.
+func MergeIconSets(sets ...*IconSet) (*IconSet, []Diagnostic) {
+	return utils.MergeIconSets(sets...)
+}
+
 // CreateIconsMap creates a map of icon names to their SVG data from the given icon data string.
 // This is a public wrapper around the internal utilities function, allowing users to create
 // custom icon maps from their own icon data files.
@@ -146,6 +329,12 @@ func CreateIconsMap(iconData string) map[string]string {
 	return utils.CreateIconsMap(iconData)
 }
 
+// CreateIconAliasesMap creates a map of alias names to their primary kind from the given icon data string.
+// Pass the result to WithIconAliases alongside WithIcons(CreateIconsMap(iconData)).
+func CreateIconAliasesMap(iconData string) map[string]string {
+	return utils.CreateIconAliasesMap(iconData)
+}
+
 // AlertCallouts will initialize the extension with the basic GFM icon set
 // This can be initialized using the `goldmark.WithExtensions(alertcallouts.AlertCallouts)` syntax
 var AlertCallouts = NewAlertCallouts(
@@ -162,12 +351,14 @@ func NewAlertCallouts(options ...Option) *alertCalloutsOptions {
 			CustomAlertsEnabled: true,                     // CustomAlerts enabled
 			DefaultIcons:        constants.ICONS_NONE,     // User will supply the iconset
 			AllowNOICON:         true,                     // Default to true
+			SVGPolicy:           DefaultSVGPolicy(),       // Sanitize icons that are not built in
 		},
 	}
 
 	for _, option := range options {
 		option(opts)
 	}
+	opts.compose()
 
 	return opts
 }
@@ -199,16 +390,46 @@ func (c *Config) GetIconKeys() []string {
 func (e *alertCalloutsOptions) Extend(m goldmark.Markdown) {
 	m.Parser().AddOptions(
 		parser.WithBlockParsers(
-			util.Prioritized(alertParser.NewAlertsParser(e.config.GetIconKeys(), e.config.FoldingEnabled, e.config.CustomAlertsEnabled), 799),
+			util.Prioritized(alertParser.NewAlertsParserWithPolicy(e.config.GetIconKeys(), e.config.FoldingEnabled, e.config.CustomAlertsEnabled, e.config.kindPolicy()), 799),
 			util.Prioritized(alertParser.NewAlertsHeaderParser(), 799),
 		),
 	)
+
+	icons := e.renderIcons()
+
+	alerts := alertRenderer.NewAlertsHTMLRenderer(icons, e.config.FoldingEnabled, e.config.DefaultIcons, e.config.CustomAlertsEnabled, e.config.AllowNOICON).(*alertRenderer.AlertsHTMLRenderer)
+	alerts.Extends = e.config.Extends
+	alerts.Aliases = e.config.Aliases
+	alerts.CanonicalKinds = e.config.CanonicalKinds
+	alerts.IconSet = e.config.IconSet
+
+	header := alertRenderer.NewAlertsHeaderHTMLRenderer(icons, e.config.FoldingEnabled, e.config.DefaultIcons, e.config.CustomAlertsEnabled, e.config.AllowNOICON).(*alertRenderer.AlertsHeaderHTMLRenderer)
+	header.IconMode = e.config.IconMode
+	header.Aliases = e.config.Aliases
+	header.SpriteURL = e.config.SpriteURL
+	header.Titles = e.config.Titles
+	header.Labels = e.config.Labels
+
 	m.Renderer().AddOptions(
 		renderer.WithNodeRenderers(
-			util.Prioritized(alertRenderer.NewAlertsHTMLRenderer(e.config.Icons, e.config.FoldingEnabled, e.config.DefaultIcons, e.config.CustomAlertsEnabled, e.config.AllowNOICON), 0),
-			util.Prioritized(alertRenderer.NewAlertsHeaderHTMLRenderer(e.config.Icons, e.config.FoldingEnabled, e.config.DefaultIcons, e.config.CustomAlertsEnabled, e.config.AllowNOICON), 0),
+			util.Prioritized(alerts, 0),
+			util.Prioritized(header, 0),
 			util.Prioritized(alertRenderer.NewAlertsBodyHTMLRenderer(), 0),
 		),
 	)
+
+	// In sprite mode without an external sprite file, each document gets its own sprite appended.
+	if e.config.IconMode == constants.ICON_MODE_SPRITE && e.config.SpriteURL == "" {
+		m.Parser().AddOptions(
+			parser.WithASTTransformers(
+				util.Prioritized(alertParser.NewSpriteTransformer(), 999),
+			),
+		)
+		m.Renderer().AddOptions(
+			renderer.WithNodeRenderers(
+				util.Prioritized(alertRenderer.NewAlertsSpriteHTMLRenderer(icons, e.config.Aliases, e.config.CustomAlertsEnabled), 0),
+			),
+		)
+	}
 }
 
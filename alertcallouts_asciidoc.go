@@ -0,0 +1,52 @@
+package alertcallouts
+
+import (
+	"github.com/yuin/goldmark/renderer"
+
+	alertRenderer "github.com/zmtcreative/gm-alert-callouts/internal/renderer"
+)
+
+type asciidocOptions struct {
+	config alertRenderer.AsciiDocConfig
+}
+
+// AsciiDocOption is a functional option for configuring the AsciiDoc renderer.
+type AsciiDocOption func(*asciidocOptions)
+
+// WithAsciiDocAdmonitions sets the admonition label (e.g. "TIP") used for each alert kind, replacing
+// the default mapping of the five GFM kinds onto NOTE, TIP, IMPORTANT, WARNING and CAUTION.
+// An alias without a label of its own, such as "hint" in the Hybrid icons, gets the label of its
+// primary kind.
+func WithAsciiDocAdmonitions(admonitions map[string]string) AsciiDocOption {
+	return func(opts *asciidocOptions) {
+		opts.config.Admonitions = admonitions
+	}
+}
+
+// WithAsciiDocFallback sets the admonition label used for kinds that have no mapping ("NOTE" by default).
+func WithAsciiDocFallback(label string) AsciiDocOption {
+	return func(opts *asciidocOptions) {
+		opts.config.Fallback = label
+	}
+}
+
+// AsciiDocRenderer returns a goldmark node renderer that writes callouts as AsciiDoc admonition
+// blocks (`[NOTE]`, `.Title`, `====` ... `====`). Aliases are resolved through the icon set of this
+// extension and any kind without a mapping uses the fallback label; whenever the label differs from
+// the kind, the kind is kept as the block's role. The body is left to the renderers of the core nodes.
+func (e *alertCalloutsOptions) AsciiDocRenderer(options ...AsciiDocOption) renderer.NodeRenderer {
+	opts := &asciidocOptions{
+		config: alertRenderer.AsciiDocConfig{
+			Aliases:     e.config.Aliases,
+			GFMKinds:    e.config.GFMKinds,
+			Admonitions: alertRenderer.AsciiDocAdmonitions,
+			Fallback:    "NOTE",
+		},
+	}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	return alertRenderer.NewAlertsAsciiDocRenderer(opts.config)
+}
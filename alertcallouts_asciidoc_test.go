@@ -0,0 +1,32 @@
+package alertcallouts
+
+import (
+	"testing"
+)
+
+func TestAsciiDocRenderer(t *testing.T) {
+	t.Run("Hybrid alias with title", func(t *testing.T) {
+		ext := NewAlertCallouts(UseHybridIcons())
+		result := renderWith(t, ext, ext.AsciiDocRenderer(), "> [!warn] Careful\n> Body")
+
+		expected := "[WARNING,role=warn]\n.Careful\n====\n====\n"
+		if result != expected {
+			t.Errorf("Expected %q, got %q", expected, result)
+		}
+	})
+
+	t.Run("Custom mapping and fallback", func(t *testing.T) {
+		ext := NewAlertCallouts(UseObsidianIcons())
+		nr := ext.AsciiDocRenderer(
+			WithAsciiDocAdmonitions(map[string]string{"bug": "WARNING"}),
+			WithAsciiDocFallback("IMPORTANT"),
+		)
+
+		if result := renderWith(t, ext, nr, "> [!bug]\n> Body"); result != "[WARNING,role=bug]\n====\n====\n" {
+			t.Errorf("Unexpected output for mapped kind: %q", result)
+		}
+		if result := renderWith(t, ext, nr, "> [!quote]\n> Body"); result != "[IMPORTANT,role=quote]\n====\n====\n" {
+			t.Errorf("Unexpected output for fallback kind: %q", result)
+		}
+	})
+}
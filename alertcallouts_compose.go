@@ -0,0 +1,215 @@
+package alertcallouts
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/zmtcreative/gm-alert-callouts/internal/constants"
+)
+
+// iconComposition collects the composition options. NewAlertCallouts applies it after all other
+// options, in a fixed order, so the position of the options does not matter.
+type iconComposition struct {
+	extra     []*IconSet        // Icon sets added on top of the base icons, later sets winning
+	kinds     []*KindRegistry   // Kind registries added after the extra icon sets, later ones winning
+	overrides map[string]string // Icon per kind, after renaming
+	renames   map[string]string // New name per kind
+	removed   []string          // Kinds to remove, after renaming
+}
+
+func (c *iconComposition) empty() bool {
+	return len(c.extra) == 0 && len(c.kinds) == 0 && len(c.overrides) == 0 && len(c.renames) == 0 && len(c.removed) == 0
+}
+
+// WithExtraIcons adds the kinds, aliases, optional fields and colors of an icon set on top of the
+// base icons chosen by a preset, UseIconSet, WithIcons or WithIconSet. Kinds of the extra icon set
+// replace base kinds of the same name. Calling it again adds another icon set, which wins over
+// the earlier ones.
+func WithExtraIcons(set *IconSet) Option {
+	return func(opts *alertCalloutsOptions) {
+		opts.composition.extra = append(slices.Clone(opts.composition.extra), set)
+	}
+}
+
+// WithIconOverrides replaces the icons of the given kinds, or adds them when they do not exist.
+// The aliases of an overridden primary kind get the new icon too, while an alias that gets an icon
+// of its own becomes a primary kind. Kinds are named as after WithRenamedKinds. Calling it again
+// adds to the overrides, later calls winning for the same kind.
+func WithIconOverrides(icons map[string]string) Option {
+	return func(opts *alertCalloutsOptions) {
+		opts.composition.overrides = withLowerKeys(opts.composition.overrides, icons)
+	}
+}
+
+// WithRenamedKinds renames kinds, mapping each old name to its new name. A renamed kind keeps its
+// icon, optional fields and aliases, and replaces any kind that already has the new name. Renames
+// apply all at once, so two kinds can swap names. Calling it again adds to the renames.
+func WithRenamedKinds(renames map[string]string) Option {
+	return func(opts *alertCalloutsOptions) {
+		lower := make(map[string]string, len(renames))
+		for from, to := range renames {
+			lower[strings.ToLower(from)] = strings.ToLower(to)
+		}
+		opts.composition.renames = withLowerKeys(opts.composition.renames, lower)
+	}
+}
+
+// WithoutKinds removes kinds from the icon set, together with the aliases of each removed kind.
+// Kinds are named as after WithRenamedKinds. Unlike WithDeniedKinds, a removed kind is still
+// accepted as a custom alert when custom alerts are enabled.
+func WithoutKinds(kinds ...string) Option {
+	return func(opts *alertCalloutsOptions) {
+		opts.composition.removed = appendKinds(opts.composition.removed, kinds)
+	}
+}
+
+// withLowerKeys returns a copy of m with the entries of add set under lower-cased keys.
+func withLowerKeys(m map[string]string, add map[string]string) map[string]string {
+	result := copyMap(m)
+	for k, v := range add {
+		result[strings.ToLower(k)] = v
+	}
+	return result
+}
+
+// compose applies the composition options to the configuration: first the extra icon sets, then
+// the kind registries, then the renames, then the overrides and finally the removals, after which
+// every alias gets the icon of its primary kind. Kinds that a registry does not allow are added to
+// the denied kinds. The maps of the configuration are replaced by copies, so maps owned by the
+// caller are never modified.
+func (e *alertCalloutsOptions) compose() {
+	comp := &e.composition
+	if comp.empty() {
+		return
+	}
+	c := &e.config
+	icons := copyMap(c.Icons)
+	aliases := copyMap(c.Aliases)
+	titles := copyMap(c.Titles)
+	labels := copyMap(c.Labels)
+	folds := copyMap(c.FoldStates)
+	gfmKinds := copyMap(c.GFMKinds)
+	extends := copyMap(c.Extends)
+
+	for _, set := range comp.extra {
+		for kind, icon := range set.Icons() {
+			icons[kind] = icon
+			delete(aliases, kind)
+			delete(titles, kind)
+			delete(labels, kind)
+			delete(folds, kind)
+			delete(gfmKinds, kind)
+		}
+		maps.Copy(aliases, set.Aliases())
+		maps.Copy(titles, set.Titles())
+		maps.Copy(labels, set.Labels())
+		maps.Copy(folds, set.FoldStates())
+		maps.Copy(gfmKinds, set.GFMKinds())
+		if colors := set.Colors(); len(colors) > 0 {
+			base := c.Colors
+			if base == nil {
+				base = constants.KIND_COLOR
+			}
+			merged := copyMap(base)
+			maps.Copy(merged, colors)
+			c.Colors = merged
+			e.addColorKinds(colors)
+		}
+	}
+
+	var denied []string
+	for _, registry := range comp.kinds {
+		denied = applyKinds(registry, icons, aliases, titles, extends, denied)
+	}
+	if len(denied) > 0 {
+		c.DeniedKinds = append(slices.Clone(c.DeniedKinds), denied...)
+	}
+
+	if len(comp.renames) > 0 {
+		rename := func(kind string) string {
+			if to, ok := comp.renames[kind]; ok {
+				return to
+			}
+			return kind
+		}
+		icons = renameKeys(icons, comp.renames)
+		renamed := renameKeys(aliases, comp.renames)
+		for from, to := range comp.renames {
+			// A primary kind renamed to the name of an alias replaces the alias.
+			if _, isAlias := aliases[from]; !isAlias {
+				delete(renamed, to)
+			}
+		}
+		aliases = renamed
+		for alias, primary := range aliases {
+			aliases[alias] = rename(primary)
+		}
+		titles = renameKeys(titles, comp.renames)
+		labels = renameKeys(labels, comp.renames)
+		folds = renameKeys(folds, comp.renames)
+		gfmKinds = renameKeys(gfmKinds, comp.renames)
+		extends = renameKeys(extends, comp.renames)
+		for kind, parent := range extends {
+			extends[kind] = rename(parent)
+		}
+	}
+
+	for kind, icon := range comp.overrides {
+		icons[kind] = icon
+		delete(aliases, kind)
+	}
+
+	for _, kind := range comp.removed {
+		for alias, primary := range aliases {
+			if primary == kind {
+				delete(icons, alias)
+				delete(aliases, alias)
+				delete(titles, alias)
+				delete(labels, alias)
+				delete(folds, alias)
+				delete(gfmKinds, alias)
+				delete(extends, alias)
+			}
+		}
+		delete(icons, kind)
+		delete(aliases, kind)
+		delete(titles, kind)
+		delete(labels, kind)
+		delete(folds, kind)
+		delete(gfmKinds, kind)
+		delete(extends, kind)
+	}
+
+	// An extra icon set or an override may have replaced the icon of a primary kind.
+	for alias, primary := range aliases {
+		if icon, ok := icons[primary]; ok {
+			icons[alias] = icon
+		}
+	}
+
+	c.Icons = icons
+	c.Aliases = aliases
+	c.Titles = titles
+	c.Labels = labels
+	c.FoldStates = folds
+	c.GFMKinds = gfmKinds
+	c.Extends = extends
+}
+
+// renameKeys returns a copy of m with its keys renamed. Renamed entries replace entries that
+// already have the new name.
+func renameKeys(m map[string]string, renames map[string]string) map[string]string {
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		if _, ok := renames[k]; !ok {
+			result[k] = v
+		}
+	}
+	for _, k := range slices.Sorted(maps.Keys(m)) {
+		if to, ok := renames[k]; ok {
+			result[to] = m[k]
+		}
+	}
+	return result
+}
@@ -0,0 +1,135 @@
+package alertcallouts
+
+import (
+	"maps"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIconComposition(t *testing.T) {
+	brand := LoadIconSet("rocket|<svg>rocket</svg>\nlaunch->rocket\nrocket.title=Shipped\nnote|<svg>brand note</svg>\n")
+
+	t.Run("Extra icon sets", func(t *testing.T) {
+		c := NewAlertCallouts(UseHybridIcons(), WithExtraIcons(brand)).GetConfig()
+		if c.Icons["rocket"] != "<svg>rocket</svg>" || c.Icons["launch"] != "<svg>rocket</svg>" || c.Aliases["launch"] != "rocket" {
+			t.Errorf("Expected the brand kinds to be added, got %q %q", c.Icons["rocket"], c.Aliases["launch"])
+		}
+		if c.Icons["note"] != "<svg>brand note</svg>" || c.Titles["rocket"] != "Shipped" {
+			t.Errorf("Expected the brand icon set to win, got %q %q", c.Icons["note"], c.Titles["rocket"])
+		}
+		if c.Icons["tip"] == "" || c.Aliases["info"] != "note" {
+			t.Error("Expected the other hybrid kinds to be kept")
+		}
+		if c.Icons["info"] != "<svg>brand note</svg>" {
+			t.Errorf("Expected the hybrid aliases of note to get the brand icon, got %q", c.Icons["info"])
+		}
+	})
+
+	t.Run("Overrides, renames and removals", func(t *testing.T) {
+		c := NewAlertCallouts(
+			UseHybridIcons(),
+			WithRenamedKinds(map[string]string{"caution": "Danger-Zone"}),
+			WithIconOverrides(map[string]string{"danger-zone": "<svg>zone</svg>", "INFO": "<svg>info</svg>"}),
+			WithoutKinds("todo", "hint"),
+		).GetConfig()
+
+		if _, ok := c.Icons["caution"]; ok || c.Icons["danger-zone"] != "<svg>zone</svg>" {
+			t.Errorf("Expected caution to be renamed and overridden, got %q", c.Icons["danger-zone"])
+		}
+		if c.Aliases["error"] != "danger-zone" {
+			t.Errorf("Expected the aliases of caution to follow the rename, got %q", c.Aliases["error"])
+		}
+		if _, isAlias := c.Aliases["info"]; isAlias || c.Icons["info"] != "<svg>info</svg>" {
+			t.Error("Expected the overridden alias to become a primary kind")
+		}
+		for _, kind := range []string{"todo", "task", "checklist", "hint"} {
+			if _, ok := c.Icons[kind]; ok {
+				t.Errorf("Expected %q to be removed", kind)
+			}
+		}
+		if c.Icons["tip"] == "" || c.Icons["hints"] == "" {
+			t.Error("Expected removing an alias to keep its primary kind and the other aliases")
+		}
+	})
+
+	t.Run("Aliases follow an overridden primary kind", func(t *testing.T) {
+		ext := NewAlertCallouts(UseHybridIcons(), WithIconOverrides(map[string]string{"note": "<svg>override</svg>"}))
+		c := ext.GetConfig()
+		if c.Icons["info"] != "<svg>override</svg>" || c.Aliases["info"] != "note" {
+			t.Errorf("Expected info to stay an alias with the new icon, got %q %q", c.Icons["info"], c.Aliases["info"])
+		}
+
+		html := convertWith(t, ext, "> [!info]\n> Body\n")
+		if !strings.Contains(html, "<svg>override</svg>") || strings.Contains(html, "lucide") {
+			t.Errorf("Expected the alias to render the overridden icon, got:\n%s", html)
+		}
+		text := renderWith(t, ext, ext.TextRenderer(WithTextEmoji(true), WithTextEmojiMap(map[string]string{"note": "N"})), "> [!info]\n> Body\n")
+		if !strings.HasPrefix(text, "N INFO") {
+			t.Errorf("Expected the alias to resolve to note in the text renderer, got %q", text)
+		}
+	})
+
+	t.Run("Rename onto an alias replaces the alias", func(t *testing.T) {
+		ext := NewAlertCallouts(UseHybridIcons(), WithRenamedKinds(map[string]string{"note": "info"}))
+		c := ext.GetConfig()
+		if _, isAlias := c.Aliases["info"]; isAlias {
+			t.Errorf("Expected info to become the primary kind, got an alias of %q", c.Aliases["info"])
+		}
+		if c.Aliases["notes"] != "info" || c.Icons["info"] != c.Icons["notes"] {
+			t.Errorf("Expected the aliases of note to follow the rename, got %q", c.Aliases["notes"])
+		}
+		if html := convertWith(t, ext, "> [!info]\n> Body\n"); strings.Contains(html, "data-callout-alias") {
+			t.Errorf("Expected info to render as a primary kind, got:\n%s", html)
+		}
+	})
+
+	t.Run("Renames apply at once", func(t *testing.T) {
+		c := NewAlertCallouts(UseGFMStrictIcons(), WithRenamedKinds(map[string]string{"note": "tip", "tip": "note"})).GetConfig()
+		gfm := NewAlertCallouts(UseGFMStrictIcons()).GetConfig()
+		if c.Icons["note"] != gfm.Icons["tip"] || c.Icons["tip"] != gfm.Icons["note"] {
+			t.Error("Expected note and tip to swap icons")
+		}
+	})
+
+	t.Run("Option order does not matter", func(t *testing.T) {
+		options := []Option{
+			WithExtraIcons(brand),
+			WithIconOverrides(map[string]string{"rocket": "<svg>override</svg>"}),
+			WithRenamedKinds(map[string]string{"warning": "heads-up"}),
+			WithoutKinds("launch"),
+		}
+		first := NewAlertCallouts(append([]Option{UseObsidianIcons()}, options...)...).GetConfig()
+		reversed := []Option{options[3], options[2], options[1], options[0], UseObsidianIcons()}
+		second := NewAlertCallouts(reversed...).GetConfig()
+
+		if !reflect.DeepEqual(first.Icons, second.Icons) || !reflect.DeepEqual(first.Aliases, second.Aliases) {
+			t.Error("Expected the same icons and aliases in any option order")
+		}
+		if first.Icons["rocket"] != "<svg>override</svg>" || first.Icons["heads-up"] == "" || first.Icons["launch"] != "" {
+			t.Errorf("Unexpected composition: %q %q", first.Icons["rocket"], first.Icons["launch"])
+		}
+	})
+
+	t.Run("Caller maps are not modified", func(t *testing.T) {
+		icons := map[string]string{"note": "<svg>note</svg>", "tip": "<svg>tip</svg>"}
+		overrides := map[string]string{"Note": "<svg>override</svg>"}
+		original := maps.Clone(icons)
+
+		c := NewAlertCallouts(
+			WithIcons(icons),
+			WithIcon("custom", "<svg>custom</svg>"),
+			WithIconOverrides(overrides),
+			WithRenamedKinds(map[string]string{"tip": "hint"}),
+			WithoutKinds("custom"),
+		).GetConfig()
+
+		if !reflect.DeepEqual(icons, original) || len(overrides) != 1 {
+			t.Errorf("Expected the caller maps to be left alone, got %v", icons)
+		}
+		expected := map[string]string{"note": "<svg>override</svg>", "hint": "<svg>tip</svg>"}
+		if !reflect.DeepEqual(c.Icons, expected) {
+			t.Errorf("Expected %v, got %v", expected, c.Icons)
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package alertcallouts
+
+import (
+	"github.com/yuin/goldmark/renderer"
+
+	alertRenderer "github.com/zmtcreative/gm-alert-callouts/internal/renderer"
+)
+
+type confluenceOptions struct {
+	config alertRenderer.ConfluenceConfig
+}
+
+// ConfluenceOption is a functional option for configuring the Confluence storage-format renderer.
+type ConfluenceOption func(*confluenceOptions)
+
+// WithConfluenceMacros sets the macro (info, tip, note or warning) used for each alert kind.
+// Only the primary kinds need an entry: aliases from the icon set are resolved to their primary
+// kind before the lookup.
+func WithConfluenceMacros(macros map[string]string) ConfluenceOption {
+	return func(opts *confluenceOptions) {
+		opts.config.Macros = macros
+	}
+}
+
+// WithConfluenceFallback sets the macro used for kinds that have no mapping ("info" by default).
+func WithConfluenceFallback(macro string) ConfluenceOption {
+	return func(opts *confluenceOptions) {
+		opts.config.Fallback = macro
+	}
+}
+
+// ConfluenceRenderer returns a goldmark node renderer that writes callouts as Confluence
+// storage-format macros: `<ac:structured-macro ac:name="info">` with an optional title parameter
+// and an `<ac:rich-text-body>` holding the body, which is left to the (XHTML) renderers of the core
+// nodes. Folded callouts become `expand` macros when folding is enabled.
+func (e *alertCalloutsOptions) ConfluenceRenderer(options ...ConfluenceOption) renderer.NodeRenderer {
+	opts := &confluenceOptions{
+		config: alertRenderer.ConfluenceConfig{
+			Aliases:        e.config.Aliases,
+			GFMKinds:       e.config.GFMKinds,
+			FoldingEnabled: e.config.FoldingEnabled,
+			AllowNOICON:    e.config.AllowNOICON,
+			Macros:         alertRenderer.ConfluenceMacros,
+			Fallback:       "info",
+		},
+	}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	return alertRenderer.NewAlertsConfluenceRenderer(opts.config)
+}
@@ -0,0 +1,56 @@
+package alertcallouts
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+func TestConfluenceRenderer(t *testing.T) {
+	t.Run("Storage format with XHTML body", func(t *testing.T) {
+		ext := NewAlertCallouts(UseHybridIcons())
+		md := goldmark.New(goldmark.WithExtensions(ext))
+		source := []byte("> [!hint] Try this\n> Body text")
+		doc := md.Parser().Parse(text.NewReader(source))
+
+		r := renderer.NewRenderer(renderer.WithNodeRenderers(
+			util.Prioritized(html.NewRenderer(html.WithXHTML()), 1000),
+			util.Prioritized(ext.ConfluenceRenderer(), 0),
+		))
+		var output strings.Builder
+		if err := r.Render(&output, source, doc); err != nil {
+			t.Fatalf("Failed to render: %v", err)
+		}
+
+		expected := "<ac:structured-macro ac:name=\"tip\"><ac:parameter ac:name=\"title\">Try this</ac:parameter><ac:rich-text-body>\n<p>Body text</p>\n</ac:rich-text-body></ac:structured-macro>\n"
+		if output.String() != expected {
+			t.Errorf("Expected %q, got %q", expected, output.String())
+		}
+	})
+
+	t.Run("Custom macros derived for aliases", func(t *testing.T) {
+		ext := NewAlertCallouts(UseObsidianIcons())
+		nr := ext.ConfluenceRenderer(WithConfluenceMacros(map[string]string{"question": "note"}), WithConfluenceFallback("tip"))
+
+		if result := renderWith(t, ext, nr, "> [!faq]\n> Body"); !strings.HasPrefix(result, `<ac:structured-macro ac:name="note">`) {
+			t.Errorf("Expected alias faq to use the macro of question, got %q", result)
+		}
+		if result := renderWith(t, ext, nr, "> [!bug]\n> Body"); !strings.HasPrefix(result, `<ac:structured-macro ac:name="tip">`) {
+			t.Errorf("Expected fallback macro, got %q", result)
+		}
+	})
+
+	t.Run("Folded callout becomes expand", func(t *testing.T) {
+		ext := NewAlertCallouts(UseObsidianIcons())
+		result := renderWith(t, ext, ext.ConfluenceRenderer(), "> [!example]- Details\n> Body")
+
+		if !strings.HasPrefix(result, `<ac:structured-macro ac:name="expand"><ac:parameter ac:name="title">Details</ac:parameter>`) {
+			t.Errorf("Expected expand macro, got %q", result)
+		}
+	})
+}
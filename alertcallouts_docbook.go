@@ -0,0 +1,52 @@
+package alertcallouts
+
+import (
+	"github.com/yuin/goldmark/renderer"
+
+	alertRenderer "github.com/zmtcreative/gm-alert-callouts/internal/renderer"
+)
+
+type docbookOptions struct {
+	config alertRenderer.DocBookConfig
+}
+
+// DocBookOption is a functional option for configuring the DocBook renderer.
+type DocBookOption func(*docbookOptions)
+
+// WithDocBookElements sets the admonition element used for each alert kind, replacing the default
+// mapping of the five GFM kinds onto <note>, <tip>, <important>, <warning> and <caution>.
+// An alias missing from the map is written with the element of its primary kind (see WithIconAliases).
+func WithDocBookElements(elements map[string]string) DocBookOption {
+	return func(opts *docbookOptions) {
+		opts.config.Elements = elements
+	}
+}
+
+// WithDocBookFallback sets the admonition element used for kinds that have no mapping ("note" by default).
+func WithDocBookFallback(element string) DocBookOption {
+	return func(opts *docbookOptions) {
+		opts.config.Fallback = element
+	}
+}
+
+// DocBookRenderer returns a goldmark node renderer that writes callouts as DocBook admonitions.
+// The five GFM kinds map onto the DocBook element of the same name; aliases are resolved through
+// the icon set of this extension, and any other kind uses the fallback element. Whenever the element
+// differs from the kind, the kind is kept in a `role` attribute. Custom titles become a <title>.
+// The body is left to the renderers of the core nodes.
+func (e *alertCalloutsOptions) DocBookRenderer(options ...DocBookOption) renderer.NodeRenderer {
+	opts := &docbookOptions{
+		config: alertRenderer.DocBookConfig{
+			Aliases:  e.config.Aliases,
+			GFMKinds: e.config.GFMKinds,
+			Elements: alertRenderer.DocBookAdmonitions,
+			Fallback: "note",
+		},
+	}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	return alertRenderer.NewAlertsDocBookRenderer(opts.config)
+}
@@ -0,0 +1,58 @@
+package alertcallouts
+
+import (
+	"testing"
+)
+
+func TestDocBookRenderer(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ext      *alertCalloutsOptions
+		options  []DocBookOption
+		input    string
+		expected string
+	}{
+		{
+			name:     "GFM strict",
+			ext:      NewAlertCallouts(UseGFMStrictIcons()),
+			input:    "> [!IMPORTANT]\n> Body",
+			expected: "<important>\n</important>\n",
+		},
+		{
+			name:     "Hybrid alias",
+			ext:      NewAlertCallouts(UseHybridIcons()),
+			input:    "> [!danger] Stop\n> Body",
+			expected: "<caution role=\"danger\">\n<title>Stop</title>\n</caution>\n",
+		},
+		{
+			name:     "Obsidian kind uses its GFM kind",
+			ext:      NewAlertCallouts(UseObsidianIcons()),
+			options:  []DocBookOption{WithDocBookFallback("sidebar")},
+			input:    "> [!example]\n> Body",
+			expected: "<note role=\"example\">\n</note>\n",
+		},
+		{
+			name:     "Custom kind uses fallback",
+			ext:      NewAlertCallouts(UseObsidianIcons()),
+			options:  []DocBookOption{WithDocBookFallback("sidebar")},
+			input:    "> [!custom]\n> Body",
+			expected: "<sidebar role=\"custom\">\n</sidebar>\n",
+		},
+		{
+			name:     "Custom elements",
+			ext:      NewAlertCallouts(UseObsidianIcons()),
+			options:  []DocBookOption{WithDocBookElements(map[string]string{"bug": "warning"})},
+			input:    "> [!bug]\n> Body",
+			expected: "<warning role=\"bug\">\n</warning>\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := renderWith(t, tc.ext, tc.ext.DocBookRenderer(tc.options...), tc.input)
+			if result != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
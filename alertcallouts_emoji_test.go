@@ -0,0 +1,65 @@
+package alertcallouts
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zmtcreative/gm-alert-callouts/internal/constants"
+)
+
+func TestEmojiIcons(t *testing.T) {
+	emoji := LoadIconSet(alertCalloutsIconsEmoji)
+
+	t.Run("Covers the Hybrid and Obsidian icon sets", func(t *testing.T) {
+		icons := emoji.Icons()
+		for _, data := range []string{alertCalloutsIconsHybrid, alertCalloutsIconsObsidian} {
+			for kind := range CreateIconsMap(data) {
+				if !strings.HasPrefix(icons[kind], constants.ICON_PREFIX_EMOJI) {
+					t.Errorf("Expected an emoji for %q, got %q", kind, icons[kind])
+				}
+			}
+		}
+	})
+
+	t.Run("Matches the emoji of the non-HTML renderers", func(t *testing.T) {
+		for kind, icon := range emoji.Icons() {
+			if _, isAlias := emoji.Aliases()[kind]; isAlias {
+				continue
+			}
+			if icon != EmojiIcon(constants.KIND_EMOJI[kind]) {
+				t.Errorf("Expected %q for %q, got %q", constants.KIND_EMOJI[kind], kind, icon)
+			}
+		}
+	})
+
+	t.Run("Preset", func(t *testing.T) {
+		result := convertWith(t, NewAlertCallouts(UseEmojiIcons()), "> [!hint]\n> a\n\n> [!custom]- Folded\n> b\n")
+		for _, expected := range []string{
+			`<div class="callout callout-hint iconset-emoji" data-callout="hint">`,
+			`<span class="callout-icon-emoji" role="img" aria-label="Hint">💡</span>`,
+			`<details class="callout callout-foldable callout-custom iconset-emoji"`,
+			`<span class="callout-icon-emoji" role="img" aria-label="Note">ℹ️</span>`,
+		} {
+			if !strings.Contains(result, expected) {
+				t.Errorf("Expected %q in:\n%s", expected, result)
+			}
+		}
+	})
+
+	t.Run("Label and title fields", func(t *testing.T) {
+		set := LoadIconSet("note|emoji:📝\nnote.label=Memo\ntip|emoji:💡\ntip.title=Pro tip\n")
+		result := convertWith(t, NewAlertCallouts(WithIconSet(set)), "> [!note]\n> a\n\n> [!tip]\n> b\n")
+		for _, expected := range []string{`aria-label="Memo">📝</span>`, `aria-label="Pro tip">💡</span>`} {
+			if !strings.Contains(result, expected) {
+				t.Errorf("Expected %q in:\n%s", expected, result)
+			}
+		}
+	})
+
+	t.Run("Go API", func(t *testing.T) {
+		result := convertWith(t, NewAlertCallouts(WithIcon("note", EmojiIcon("<📝>"))), "> [!note]\n> a\n")
+		if !strings.Contains(result, `<span class="callout-icon-emoji" role="img" aria-label="Note">&lt;📝&gt;</span>`) {
+			t.Errorf("Expected an escaped emoji, got:\n%s", result)
+		}
+	})
+}
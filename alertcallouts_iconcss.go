@@ -0,0 +1,20 @@
+package alertcallouts
+
+import (
+	alertRenderer "github.com/zmtcreative/gm-alert-callouts/internal/renderer"
+)
+
+// CreateIconStylesheet returns a stylesheet for the IconsCSS icon mode from any icon map, such as
+// the output of CreateIconsMap. Each icon becomes a `mask-image` data URI on the
+// `.callout-icon` span of every kind and alias that uses it; the span is painted with
+// `currentColor`. Kinds without an icon of their own use the first icon of the fallback list
+// (default, icon, custom, note, info) found in the map.
+func CreateIconStylesheet(icons map[string]string) string {
+	return alertRenderer.IconStylesheet(icons, true)
+}
+
+// IconStylesheet returns the IconsCSS stylesheet for the icon set of this extension.
+// The fallback icon rule is only included when custom alerts are enabled.
+func (e *alertCalloutsOptions) IconStylesheet() string {
+	return alertRenderer.IconStylesheet(e.renderIcons(), e.config.CustomAlertsEnabled)
+}
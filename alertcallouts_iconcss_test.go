@@ -0,0 +1,42 @@
+package alertcallouts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCSSIconMode(t *testing.T) {
+	ext := NewAlertCallouts(UseHybridIcons(), WithIconMode(IconsCSS))
+	result := convertWith(t, ext, "> [!info] Title\n> Body\n")
+
+	if !strings.Contains(result, `<div class="callout-title">`+"\n"+`<span class="callout-icon"></span><p class="callout-title-text">Title</p>`) {
+		t.Errorf("Expected an empty icon span, got:\n%s", result)
+	}
+	if strings.Contains(result, "<svg") {
+		t.Errorf("Expected no SVG in the HTML, got:\n%s", result)
+	}
+}
+
+func TestIconStylesheet(t *testing.T) {
+	t.Run("Built-in icon sets cover every kind and alias", func(t *testing.T) {
+		for name, option := range map[string]Option{"gfm": UseGFMStrictIcons(), "hybrid": UseHybridIcons(), "obsidian": UseObsidianIcons()} {
+			ext := NewAlertCallouts(option)
+			css := ext.IconStylesheet()
+			for kind := range ext.GetConfig().Icons {
+				if !strings.Contains(css, ".callout-"+kind+" > .callout-title > .callout-icon") {
+					t.Errorf("%s: expected a rule for %q", name, kind)
+				}
+			}
+		}
+	})
+
+	t.Run("Works with CreateIconsMap output", func(t *testing.T) {
+		css := CreateIconStylesheet(CreateIconsMap("brand|<svg viewBox=\"0 0 8 8\"><path d=\"M0 0\"/></svg>\nlogo->brand"))
+		if !strings.Contains(css, ".callout-brand > .callout-title > .callout-icon,\n.callout-logo > .callout-title > .callout-icon {") {
+			t.Errorf("Expected the alias to share the rule of its primary, got:\n%s", css)
+		}
+		if !strings.Contains(css, `mask-image: url("data:image/svg+xml,%3Csvg xmlns='http://www.w3.org/2000/svg' viewBox='0 0 8 8'%3E`) {
+			t.Errorf("Expected an SVG data URI, got:\n%s", css)
+		}
+	})
+}
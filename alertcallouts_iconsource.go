@@ -0,0 +1,29 @@
+package alertcallouts
+
+import (
+	"strings"
+
+	"github.com/zmtcreative/gm-alert-callouts/internal/constants"
+)
+
+// ImageIcon returns an icon for an image URL or a data URI, which the header renders as
+// `<img class="callout-icon-image" src="..." alt="">`. Use it anywhere an icon is expected, such as
+// WithIcon or KindDefinition.Icon; in the .icons format write `note|image:https://...`.
+func ImageIcon(src string) string {
+	return constants.ICON_PREFIX_IMAGE + src
+}
+
+// FontIcon returns an icon for an icon font such as Font Awesome, which the header renders as
+// `<i class="callout-icon-font fa-solid fa-circle-info" aria-hidden="true"></i>`. In the .icons
+// format write `note|font:fa-solid fa-circle-info`.
+func FontIcon(classes ...string) string {
+	return constants.ICON_PREFIX_FONT + strings.Join(classes, " ")
+}
+
+// EmojiIcon returns an icon for a Unicode emoji, which the header renders as
+// `<span class="callout-icon-emoji" role="img" aria-label="Tip">💡</span>`. The label is the label
+// field of the kind, its default title or the title-cased kind. In the .icons format write
+// `tip|emoji:💡`.
+func EmojiIcon(emoji string) string {
+	return constants.ICON_PREFIX_EMOJI + emoji
+}
@@ -0,0 +1,60 @@
+package alertcallouts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIconSources(t *testing.T) {
+	source := "> [!note]\n> a\n\n> [!tip]\n> b\n\n> [!info]\n> c\n"
+	iconData := "note|image:https://cdn.example.com/note.png\n" +
+		"tip|font:fa-solid fa-lightbulb\n" +
+		"info|<svg viewBox=\"0 0 24 24\"><path d=\"M1 1\"/></svg>\n"
+	image := `<img class="callout-icon-image" src="https://cdn.example.com/note.png" alt="">`
+	font := `<i class="callout-icon-font fa-solid fa-lightbulb" aria-hidden="true"></i>`
+
+	t.Run("Icon file", func(t *testing.T) {
+		result := convertWith(t, NewAlertCallouts(WithIconSet(LoadIconSet(iconData))), source)
+		for _, expected := range []string{image, font, `<path d="M1 1"/>`} {
+			if !strings.Contains(result, expected) {
+				t.Errorf("Expected %q in:\n%s", expected, result)
+			}
+		}
+	})
+
+	t.Run("Go API", func(t *testing.T) {
+		ext := NewAlertCallouts(
+			WithIcon("note", ImageIcon("https://cdn.example.com/note.png")),
+			WithIcon("tip", FontIcon("fa-solid", "fa-lightbulb")),
+		)
+		result := convertWith(t, ext, source)
+		if !strings.Contains(result, image) || !strings.Contains(result, font) {
+			t.Errorf("Expected an image and a font icon, got:\n%s", result)
+		}
+	})
+
+	t.Run("Sprite and CSS modes write them inline", func(t *testing.T) {
+		for _, mode := range []IconMode{IconsSprite, IconsCSS} {
+			ext := NewAlertCallouts(WithIconSet(LoadIconSet(iconData)), WithIconMode(mode))
+			result := convertWith(t, ext, source)
+			if !strings.Contains(result, image) || !strings.Contains(result, font) {
+				t.Errorf("Expected an image and a font icon in mode %d, got:\n%s", mode, result)
+			}
+			if strings.Contains(ext.IconSprite(), "note") {
+				t.Errorf("Expected no symbol for the image icon in mode %d", mode)
+			}
+		}
+	})
+
+	t.Run("Unsafe sources are removed", func(t *testing.T) {
+		ext := NewAlertCallouts(WithIcon("note", ImageIcon("javascript:alert(1)")), WithIcon("tip", FontIcon(`x" onclick="alert(1)`)))
+		if result := convertWith(t, ext, source); strings.Contains(result, "alert(") {
+			t.Errorf("Expected the unsafe icons to be removed, got:\n%s", result)
+		}
+
+		_, diagnostics, _ := ParseIconSet(strings.NewReader("note|image:data:text/html,x\n"))
+		if len(diagnostics) != 1 || diagnostics[0].Reason != `icon "note" sanitized: removed icon: data URI that is not an image` {
+			t.Errorf("Unexpected diagnostics: %v", diagnostics)
+		}
+	})
+}
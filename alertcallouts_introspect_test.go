@@ -0,0 +1,66 @@
+package alertcallouts
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIconSetIntrospection(t *testing.T) {
+	t.Run("Presets", func(t *testing.T) {
+		for _, name := range Presets() {
+			set, ok := LookupIconSet(name)
+			if !ok {
+				t.Fatalf("Expected preset %q", name)
+			}
+			count := len(set.Primaries())
+			for _, primary := range set.Primaries() {
+				for _, alias := range set.AliasesOf(primary) {
+					if got, _ := set.PrimaryOf(alias); got != primary {
+						t.Errorf("%s: expected PrimaryOf(%q) to be %q, got %q", name, alias, primary, got)
+					}
+					count++
+				}
+			}
+			if count != len(set.Kinds()) {
+				t.Errorf("%s: expected the primaries and their aliases to cover all %d kinds, got %d", name, len(set.Kinds()), count)
+			}
+		}
+
+		gfm, _ := LookupIconSet("gfm")
+		if primaries := gfm.Primaries(); !reflect.DeepEqual(primaries, []string{"note", "tip", "important", "warning", "caution"}) {
+			t.Errorf("Expected the GFM kinds in file order, got %v", primaries)
+		}
+	})
+
+	t.Run("Hybrid", func(t *testing.T) {
+		set, _ := LookupIconSet("Hybrid")
+		if aliases := set.AliasesOf("note"); !reflect.DeepEqual(aliases, []string{"notes", "info", "information"}) {
+			t.Errorf("Unexpected aliases of note: %v", aliases)
+		}
+		groups := set.Groups()
+		if len(groups) != 2 || len(groups[0].Kinds) != len(set.Primaries()) || len(groups[0].Kinds)+len(groups[1].Kinds) != len(set.Kinds()) {
+			t.Fatalf("Expected a group of primaries and a group of aliases, got %+v", groups)
+		}
+		if groups[1].Comments[0] != "Alias definitions (format: alias->primary_key)" {
+			t.Errorf("Unexpected comments: %v", groups[1].Comments)
+		}
+	})
+
+	t.Run("Registered and unknown icon sets", func(t *testing.T) {
+		brand := LoadIconSet("rocket|<svg/>\n")
+		if err := RegisterIconSet("introspect", brand); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		t.Cleanup(func() {
+			iconSetsMu.Lock()
+			delete(iconSets, "introspect")
+			iconSetsMu.Unlock()
+		})
+		if set, ok := LookupIconSet("introspect"); !ok || set != brand {
+			t.Error("Expected the registered icon set")
+		}
+		if _, ok := LookupIconSet("missing"); ok {
+			t.Error("Expected unknown names to be reported")
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package alertcallouts
+
+import (
+	"github.com/yuin/goldmark/renderer"
+
+	alertRenderer "github.com/zmtcreative/gm-alert-callouts/internal/renderer"
+)
+
+type jiraOptions struct {
+	config alertRenderer.JiraConfig
+}
+
+// JiraOption is a functional option for configuring the Jira wiki markup renderer.
+type JiraOption func(*jiraOptions)
+
+// WithJiraMacros sets the macro (info, tip, note or warning) used for each alert kind.
+// An alias without a macro of its own takes the macro of its primary kind; other kinds without a
+// macro are written as panels.
+func WithJiraMacros(macros map[string]string) JiraOption {
+	return func(opts *jiraOptions) {
+		opts.config.Macros = macros
+	}
+}
+
+// WithJiraPanels sets whether every callout is written as a {panel} instead of a macro.
+func WithJiraPanels(enable bool) JiraOption {
+	return func(opts *jiraOptions) {
+		opts.config.Panels = enable
+	}
+}
+
+// WithJiraWidth sets the column that paragraphs are wrapped at. A width of 0 disables wrapping.
+func WithJiraWidth(width int) JiraOption {
+	return func(opts *jiraOptions) {
+		opts.config.Width = width
+	}
+}
+
+// JiraRenderer returns a goldmark node renderer that writes callouts as Jira wiki markup.
+// Kinds with a macro mapping (the same default mapping as the Confluence renderer) are written as
+// `{note:title=…}…{note}`; all other kinds become `{panel:title=…|borderColor=…}…{panel}`, using
+// the accent color set with WithColors. The body is written as plain text.
+func (e *alertCalloutsOptions) JiraRenderer(options ...JiraOption) renderer.NodeRenderer {
+	opts := &jiraOptions{
+		config: alertRenderer.JiraConfig{
+			Aliases:     e.config.Aliases,
+			GFMKinds:    e.config.GFMKinds,
+			AllowNOICON: e.config.AllowNOICON,
+			Macros:      alertRenderer.ConfluenceMacros,
+			Colors:      e.config.Colors,
+		},
+	}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	return alertRenderer.NewAlertsJiraRenderer(opts.config)
+}
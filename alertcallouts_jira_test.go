@@ -0,0 +1,38 @@
+package alertcallouts
+
+import (
+	"testing"
+)
+
+func TestJiraRenderer(t *testing.T) {
+	t.Run("GFM kinds use macros", func(t *testing.T) {
+		ext := NewAlertCallouts(UseGFMStrictIcons())
+		result := renderWith(t, ext, ext.JiraRenderer(), "> [!CAUTION]\n> Body")
+
+		expected := "{warning}\nBody\n{warning}\n"
+		if result != expected {
+			t.Errorf("Expected %q, got %q", expected, result)
+		}
+	})
+
+	t.Run("Panels use the extension colors", func(t *testing.T) {
+		ext := NewAlertCallouts(UseHybridIcons(), WithColors(map[string]string{"bug": "123456"}))
+		nr := ext.JiraRenderer(WithJiraMacros(map[string]string{}))
+		result := renderWith(t, ext, nr, "> [!bug] Crash\n> Body")
+
+		expected := "{panel:title=Crash|borderColor=#123456}\nBody\n{panel}\n"
+		if result != expected {
+			t.Errorf("Expected %q, got %q", expected, result)
+		}
+	})
+
+	t.Run("Forced panels with wrapping", func(t *testing.T) {
+		ext := NewAlertCallouts(UseHybridIcons())
+		result := renderWith(t, ext, ext.JiraRenderer(WithJiraPanels(true), WithJiraWidth(10)), "> [!hint]\n> alpha beta gamma")
+
+		expected := "{panel:title=Hint|borderColor=#1A7F37}\nalpha beta\ngamma\n{panel}\n"
+		if result != expected {
+			t.Errorf("Expected %q, got %q", expected, result)
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package alertcallouts
+
+import (
+	"encoding/json"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+
+	alertRenderer "github.com/zmtcreative/gm-alert-callouts/internal/renderer"
+)
+
+// CalloutSchemaVersion is the version of the JSON export schema (see docs/FEATURES.md).
+const CalloutSchemaVersion = alertRenderer.CalloutSchemaVersion
+
+// Fold states reported in the FoldState field of an exported callout.
+const (
+	FoldStateNone   = alertRenderer.FoldStateNone
+	FoldStateOpen   = alertRenderer.FoldStateOpen
+	FoldStateClosed = alertRenderer.FoldStateClosed
+)
+
+// CalloutDocument is the top-level object of the JSON export: the schema version and the callouts of a document.
+type CalloutDocument = alertRenderer.CalloutDocument
+
+// Callout is the exported form of a single callout, with any nested callouts as its children.
+type Callout = alertRenderer.Callout
+
+// CalloutSourceRange locates an exported callout in the markdown source.
+type CalloutSourceRange = alertRenderer.CalloutSourceRange
+
+// ExportCallouts collects the callouts of a document that was parsed by a goldmark instance with
+// this extension installed. The body of each callout is rendered with html, normally the Renderer()
+// of the same goldmark instance; when html is nil a default HTML renderer with this extension is used.
+func (e *alertCalloutsOptions) ExportCallouts(doc ast.Node, source []byte, html renderer.Renderer) (*CalloutDocument, error) {
+	if html == nil {
+		html = goldmark.New(goldmark.WithExtensions(e)).Renderer()
+	}
+	exporter := alertRenderer.NewCalloutExporter(alertRenderer.ExportConfig{
+		Aliases:        e.config.Aliases,
+		FoldingEnabled: e.config.FoldingEnabled,
+		AllowNOICON:    e.config.AllowNOICON,
+		Titles:         e.config.Titles,
+	})
+	return exporter.Export(doc, source, html)
+}
+
+// CalloutsJSON parses source with md, which must have this extension installed, and returns the
+// callouts of the document as JSON, ready for a headless CMS or a search index.
+func (e *alertCalloutsOptions) CalloutsJSON(md goldmark.Markdown, source []byte) ([]byte, error) {
+	doc := md.Parser().Parse(text.NewReader(source))
+	callouts, err := e.ExportCallouts(doc, source, md.Renderer())
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(callouts)
+}
@@ -0,0 +1,80 @@
+package alertcallouts
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/text"
+)
+
+func TestCalloutsJSON(t *testing.T) {
+	ext := NewAlertCallouts(UseHybridIcons())
+	md := goldmark.New(goldmark.WithExtensions(ext))
+	source := []byte("> [!info]+ Heads up\n> Body\n>\n> > [!hint]\n> > Nested\n")
+
+	data, err := ext.CalloutsJSON(md, source)
+	if err != nil {
+		t.Fatalf("CalloutsJSON returned error: %v", err)
+	}
+
+	var result CalloutDocument
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, data)
+	}
+	if result.Version != CalloutSchemaVersion || len(result.Callouts) != 1 {
+		t.Fatalf("Unexpected document: %s", data)
+	}
+
+	c := result.Callouts[0]
+	if c.Kind != "info" || c.CanonicalKind != "note" || c.Title != "Heads up" || c.FoldState != FoldStateOpen {
+		t.Errorf("Unexpected callout: %+v", c)
+	}
+	if !strings.Contains(c.BodyHTML, `<div class="callout callout-hint iconset-hybrid" data-callout="hint">`) {
+		t.Errorf("Expected the nested callout rendered with the extension, got %q", c.BodyHTML)
+	}
+	if len(c.Children) != 1 || c.Children[0].Kind != "hint" || c.Children[0].CanonicalKind != "tip" {
+		t.Errorf("Unexpected children: %+v", c.Children)
+	}
+}
+
+func TestExportCallouts(t *testing.T) {
+	t.Run("Default HTML renderer", func(t *testing.T) {
+		ext := NewAlertCallouts(UseGFMStrictIcons())
+		source := []byte("> [!NOTE]\n> Body\n")
+		doc := goldmark.New(goldmark.WithExtensions(ext)).Parser().Parse(text.NewReader(source))
+
+		result, err := ext.ExportCallouts(doc, source, nil)
+		if err != nil {
+			t.Fatalf("ExportCallouts returned error: %v", err)
+		}
+		if len(result.Callouts) != 1 || result.Callouts[0].BodyHTML != "<p>Body</p>\n" {
+			t.Errorf("Unexpected export: %+v", result)
+		}
+	})
+
+	t.Run("Custom icon sets need their aliases", func(t *testing.T) {
+		iconData := "note|<svg>note</svg>\ninfo->note"
+		source := []byte("> [!info]\n> Body\n")
+
+		for _, tc := range []struct {
+			options   []Option
+			canonical string
+		}{
+			{[]Option{WithIcons(CreateIconsMap(iconData))}, "info"},
+			{[]Option{WithIcons(CreateIconsMap(iconData)), WithIconAliases(CreateIconAliasesMap(iconData))}, "note"},
+			{[]Option{UseHybridIcons(), WithIcons(CreateIconsMap(iconData))}, "info"},
+		} {
+			ext := NewAlertCallouts(tc.options...)
+			doc := goldmark.New(goldmark.WithExtensions(ext)).Parser().Parse(text.NewReader(source))
+			result, err := ext.ExportCallouts(doc, source, nil)
+			if err != nil {
+				t.Fatalf("ExportCallouts returned error: %v", err)
+			}
+			if got := result.Callouts[0].CanonicalKind; got != tc.canonical {
+				t.Errorf("Expected canonical kind %q, got %q", tc.canonical, got)
+			}
+		}
+	})
+}
@@ -0,0 +1,158 @@
+package alertcallouts
+
+import (
+	"slices"
+	"strings"
+
+	alertParser "github.com/zmtcreative/gm-alert-callouts/internal/parser"
+	utils "github.com/zmtcreative/gm-alert-callouts/internal/utilities"
+)
+
+// KindDefinition describes a single alert kind: its name, aliases, icon, default title, whether
+// the parser rejects it and which kind it extends. A kind that extends another also gets the
+// CSS class of its parent, and its icon when it has none of its own.
+type KindDefinition = utils.KindDefinition
+
+// KindRegistry holds kind definitions independently of any icon map.
+type KindRegistry = utils.KindRegistry
+
+// NewKindRegistry returns a registry holding the given kind definitions.
+func NewKindRegistry(defs ...KindDefinition) *KindRegistry {
+	return utils.NewKindRegistry(defs...)
+}
+
+// KindRegistryFromIconSet returns a registry with a kind for every primary kind of an
+// icon set, so the kinds of an existing icon set can be adjusted and passed to WithKinds.
+func KindRegistryFromIconSet(set *IconSet) *KindRegistry {
+	return utils.KindRegistryFromIconSet(set)
+}
+
+// WithKinds adds the kinds of a registry to the configuration. Each kind and its aliases get the
+// icon (inherited from the parent kind when empty, or kept when neither has one) and default title
+// of their definition, kinds
+// that extend another kind get its CSS class, and kinds marked Denied are denied.
+// NewAlertCallouts applies the registries after the base icons and extra icon sets, in the order
+// they were added, so presets and icon set options may come before or after them. Calling it
+// again adds another registry, whose definitions win over the earlier ones.
+func WithKinds(registry *KindRegistry) Option {
+	return func(opts *alertCalloutsOptions) {
+		opts.composition.kinds = append(slices.Clone(opts.composition.kinds), registry)
+	}
+}
+
+// applyKinds adds the kinds of a registry to the icon, alias, title and extends maps, and returns
+// denied with the denied kinds of the registry added and its other kinds removed.
+func applyKinds(registry *KindRegistry, icons, aliases, titles, extends map[string]string, denied []string) []string {
+	for _, name := range registry.Kinds() {
+		def, _ := registry.Definition(name)
+		icon := def.Icon
+		for _, parent := range registry.Parents(name) {
+			if icon != "" {
+				break
+			}
+			if p, ok := registry.Definition(parent); ok && p.Icon != "" {
+				icon = p.Icon
+			} else {
+				icon = icons[parent]
+			}
+		}
+		parent := ""
+		if def.Extends != "" {
+			parent = def.Extends
+			if p := registry.Primary(parent); p != "" {
+				parent = p
+			}
+		}
+
+		for _, kind := range append([]string{name}, def.Aliases...) {
+			// Without an icon of its own or from a parent, a kind keeps the icon it already has.
+			if _, ok := icons[kind]; icon != "" || !ok {
+				icons[kind] = icon
+			}
+			if kind != name {
+				aliases[kind] = name
+			} else {
+				delete(aliases, kind)
+			}
+			if def.Title != "" {
+				titles[kind] = def.Title
+			}
+			if parent != "" {
+				extends[kind] = parent
+			} else {
+				delete(extends, kind)
+			}
+			denied = slices.DeleteFunc(denied, func(k string) bool { return k == kind })
+			if def.Denied {
+				denied = append(denied, kind)
+			}
+		}
+	}
+	return denied
+}
+
+// WithKind adds a single kind definition to the configuration (see WithKinds).
+func WithKind(def KindDefinition) Option {
+	return WithKinds(NewKindRegistry(def))
+}
+
+// WithAllowedKinds limits the kinds the parser accepts to the given kinds and their aliases.
+// Allowed kinds do not need an icon, even when custom alerts are disabled; other kinds render
+// as plain blockquotes. Calling it again adds to the list.
+func WithAllowedKinds(kinds ...string) Option {
+	return func(opts *alertCalloutsOptions) {
+		opts.config.AllowedKinds = appendKinds(opts.config.AllowedKinds, kinds)
+	}
+}
+
+// WithDeniedKinds stops the parser from accepting the given kinds and their aliases, even when
+// they have an icon or custom alerts are enabled. Denied kinds render as plain blockquotes.
+// Calling it again adds to the list.
+func WithDeniedKinds(kinds ...string) Option {
+	return func(opts *alertCalloutsOptions) {
+		opts.config.DeniedKinds = appendKinds(opts.config.DeniedKinds, kinds)
+	}
+}
+
+// appendKinds returns a copy of list with the lower-cased kinds appended.
+func appendKinds(list []string, kinds []string) []string {
+	result := slices.Clone(list)
+	for _, kind := range kinds {
+		result = append(result, strings.ToLower(kind))
+	}
+	return result
+}
+
+// copyMap returns a copy of m that is safe to modify (an empty map when m is nil).
+func copyMap(m map[string]string) map[string]string {
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	return result
+}
+
+// kindPolicy returns the allow and deny lists for the parser, each extended with the aliases of
+// the kinds it names, and the default fold states.
+func (c *Config) kindPolicy() alertParser.KindPolicy {
+	return alertParser.KindPolicy{
+		Allow: c.withAliases(c.AllowedKinds),
+		Deny:  c.withAliases(c.DeniedKinds),
+		Folds: c.FoldStates,
+	}
+}
+
+// withAliases returns the sorted kinds together with every alias whose primary kind is among them.
+func (c *Config) withAliases(kinds []string) []string {
+	if len(kinds) == 0 {
+		return nil
+	}
+	result := slices.Clone(kinds)
+	for alias, primary := range c.Aliases {
+		if slices.Contains(kinds, primary) {
+			result = append(result, alias)
+		}
+	}
+	slices.Sort(result)
+	return slices.Compact(result)
+}
@@ -0,0 +1,141 @@
+package alertcallouts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestKindRegistryOptions(t *testing.T) {
+	t.Run("Registered kind extends a built-in kind", func(t *testing.T) {
+		ext := NewAlertCallouts(UseHybridIcons(), WithKind(KindDefinition{
+			Name:    "security",
+			Aliases: []string{"vuln"},
+			Title:   "Security",
+			Extends: "warning",
+		}))
+		result := convertWith(t, ext, "> [!vuln]\n> a\n")
+
+		if !strings.Contains(result, `<div class="callout callout-vuln callout-warning iconset-hybrid" data-callout="vuln">`) {
+			t.Errorf("Expected the parent class on the alias, got:\n%s", result)
+		}
+		if !strings.Contains(result, `<p class="callout-title-text">Security</p>`) {
+			t.Errorf("Expected the default title, got:\n%s", result)
+		}
+		config := ext.GetConfig()
+		if config.Icons["security"] != config.Icons["warning"] || config.Aliases["vuln"] != "security" {
+			t.Errorf("Expected security to inherit the warning icon and own the vuln alias")
+		}
+	})
+
+	t.Run("Registered kind without icon in strict mode", func(t *testing.T) {
+		ext := NewAlertCallouts(UseGFMStrictIcons(), WithKind(KindDefinition{Name: "security"}))
+		result := convertWith(t, ext, "> [!security]\n> a\n")
+		if !strings.Contains(result, `data-callout="security"`) {
+			t.Errorf("Expected the registered kind to be accepted, got:\n%s", result)
+		}
+	})
+
+	t.Run("Kinds are accepted unless denied", func(t *testing.T) {
+		ext := NewAlertCallouts(UseHybridIcons(), WithKind(KindDefinition{Name: "security", Extends: "warning"}))
+		result := convertWith(t, ext, "> [!security]\n> a\n")
+		if !strings.Contains(result, `data-callout="security"`) || len(ext.GetConfig().DeniedKinds) != 0 {
+			t.Errorf("Expected a definition without Denied to be accepted, got:\n%s", result)
+		}
+	})
+
+	t.Run("Denied kind renders as blockquote", func(t *testing.T) {
+		ext := NewAlertCallouts(UseHybridIcons(), WithKind(KindDefinition{Name: "draft", Denied: true}))
+		result := convertWith(t, ext, "> [!draft]\n> a\n")
+		if !strings.Contains(result, "<blockquote>") {
+			t.Errorf("Expected a plain blockquote, got:\n%s", result)
+		}
+	})
+
+	t.Run("Option order does not matter", func(t *testing.T) {
+		draft := WithKind(KindDefinition{Name: "draft", Denied: true})
+		security := WithKind(KindDefinition{Name: "security", Aliases: []string{"vuln"}, Title: "Security", Extends: "warning"})
+		before := NewAlertCallouts(draft, security, UseObsidianIcons())
+		after := NewAlertCallouts(UseObsidianIcons(), draft, security)
+
+		source := "> [!vuln]\n> a\n\n> [!draft]\n> b\n"
+		if got, expected := convertWith(t, before, source), convertWith(t, after, source); got != expected {
+			t.Errorf("Expected the same output with the preset last, got:\n%s\nexpected:\n%s", got, expected)
+		}
+		config := before.GetConfig()
+		if config.Icons["security"] != config.Icons["warning"] || config.Extends["vuln"] != "warning" || config.Titles["vuln"] != "Security" {
+			t.Errorf("Expected the registered kind on top of the later preset, got %+v", config)
+		}
+	})
+
+	t.Run("Later registries win and denied kinds stay denied", func(t *testing.T) {
+		ext := NewAlertCallouts(
+			UseHybridIcons(),
+			WithKind(KindDefinition{Name: "draft", Denied: true}),
+			WithKind(KindDefinition{Name: "draft"}),
+			WithDeniedKinds("security"),
+			WithKind(KindDefinition{Name: "security"}),
+		)
+		if result := convertWith(t, ext, "> [!draft]\n> a\n"); !strings.Contains(result, `data-callout="draft"`) {
+			t.Errorf("Expected the later registry to allow draft, got:\n%s", result)
+		}
+		if result := convertWith(t, ext, "> [!security]\n> a\n"); !strings.Contains(result, "<blockquote>") {
+			t.Errorf("Expected WithDeniedKinds to win over a registry, got:\n%s", result)
+		}
+	})
+
+	t.Run("Redefined kind keeps its icon", func(t *testing.T) {
+		ext := NewAlertCallouts(UseHybridIcons(), WithKind(KindDefinition{Name: "note", Title: "Heads up"}))
+		icon := NewAlertCallouts(UseHybridIcons()).GetConfig().Icons["note"]
+		for _, kind := range []string{"note", "info", "notes"} {
+			if got := ext.GetConfig().Icons[kind]; got != icon || icon == "" {
+				t.Errorf("Expected %s to keep the note icon, got %q", kind, got)
+			}
+		}
+		result := convertWith(t, ext, "> [!note]\n> a\n")
+		if !strings.Contains(result, "<svg") || !strings.Contains(result, `<p class="callout-title-text">Heads up</p>`) {
+			t.Errorf("Expected the icon and the new title, got:\n%s", result)
+		}
+	})
+
+	t.Run("Caller maps are not modified", func(t *testing.T) {
+		icons := map[string]string{"note": "<svg/>"}
+		NewAlertCallouts(WithIcons(icons), WithKind(KindDefinition{Name: "extra"}))
+		if len(icons) != 1 {
+			t.Errorf("Expected the icons map to be left alone, got %v", icons)
+		}
+	})
+}
+
+func TestAllowedAndDeniedKinds(t *testing.T) {
+	source := "> [!note]\n> a\n\n> [!info]\n> b\n\n> [!tip]\n> c\n\n> [!custom]\n> d\n"
+
+	t.Run("Deny list includes aliases", func(t *testing.T) {
+		ext := NewAlertCallouts(UseHybridIcons(), WithDeniedKinds("NOTE"))
+		result := convertWith(t, ext, source)
+		if strings.Contains(result, `data-callout="note"`) || strings.Contains(result, `data-callout="info"`) {
+			t.Errorf("Expected note and its alias info to be denied, got:\n%s", result)
+		}
+		if !strings.Contains(result, `data-callout="tip"`) || !strings.Contains(result, `data-callout="custom"`) {
+			t.Errorf("Expected other kinds to be accepted, got:\n%s", result)
+		}
+	})
+
+	t.Run("Allow list includes aliases", func(t *testing.T) {
+		ext := NewAlertCallouts(UseHybridIcons(), WithAllowedKinds("note"))
+		result := convertWith(t, ext, source)
+		if !strings.Contains(result, `data-callout="note"`) || !strings.Contains(result, `data-callout="info"`) {
+			t.Errorf("Expected note and its alias info to be allowed, got:\n%s", result)
+		}
+		if strings.Contains(result, `data-callout="tip"`) || strings.Contains(result, `data-callout="custom"`) {
+			t.Errorf("Expected other kinds to be rejected, got:\n%s", result)
+		}
+	})
+
+	t.Run("Allow list works without icons", func(t *testing.T) {
+		ext := NewAlertCallouts(UseGFMStrictIcons(), WithAllowedKinds("custom"))
+		result := convertWith(t, ext, source)
+		if !strings.Contains(result, `data-callout="custom"`) || strings.Contains(result, `data-callout="note"`) {
+			t.Errorf("Expected only custom to be accepted, got:\n%s", result)
+		}
+	})
+}
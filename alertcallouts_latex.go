@@ -0,0 +1,86 @@
+package alertcallouts
+
+import (
+	"github.com/yuin/goldmark/renderer"
+
+	alertRenderer "github.com/zmtcreative/gm-alert-callouts/internal/renderer"
+)
+
+// LaTeXIconMode selects how icons are represented in LaTeX output.
+type LaTeXIconMode = alertRenderer.LaTeXIconMode
+
+const (
+	// LaTeXIconsSymbol replaces the SVG icons with commands from a symbol font package (fontawesome5 by default).
+	LaTeXIconsSymbol = alertRenderer.LaTeXIconsSymbol
+	// LaTeXIconsNone omits icons from the callout titles.
+	LaTeXIconsNone = alertRenderer.LaTeXIconsNone
+)
+
+type latexOptions struct {
+	config alertRenderer.LaTeXConfig
+}
+
+// LaTeXOption is a functional option for configuring the LaTeX renderer and preamble.
+type LaTeXOption func(*latexOptions)
+
+// WithLaTeXIcons sets how icons are represented in LaTeX output.
+func WithLaTeXIcons(mode LaTeXIconMode) LaTeXOption {
+	return func(opts *latexOptions) {
+		opts.config.IconMode = mode
+	}
+}
+
+// WithLaTeXSymbols sets the package that provides the symbol font and the symbol command for each
+// alert kind, e.g. WithLaTeXSymbols("fontawesome5", map[string]string{"note": `\faInfoCircle`}).
+// The environment of an alias gets the symbol of its primary kind unless the map names the alias.
+func WithLaTeXSymbols(pkg string, symbols map[string]string) LaTeXOption {
+	return func(opts *latexOptions) {
+		opts.config.SymbolPackage = pkg
+		opts.config.Symbols = symbols
+	}
+}
+
+// WithLaTeXColors sets the accent color for each alert kind as a six-digit hex value (e.g. "0969DA"),
+// overriding the extension's WithColors map for the preamble.
+// Alias environments without a color of their own are drawn in the color of their primary kind.
+func WithLaTeXColors(colors map[string]string) LaTeXOption {
+	return func(opts *latexOptions) {
+		opts.config.Colors = colors
+	}
+}
+
+func (e *alertCalloutsOptions) latexConfig(options []LaTeXOption) alertRenderer.LaTeXConfig {
+	opts := &latexOptions{
+		config: alertRenderer.LaTeXConfig{
+			Icons:         e.config.Icons,
+			Aliases:       e.config.Aliases,
+			GFMKinds:      e.config.GFMKinds,
+			AllowNOICON:   e.config.AllowNOICON,
+			Colors:        e.config.Colors,
+			IconMode:      LaTeXIconsSymbol,
+			SymbolPackage: "fontawesome5",
+		},
+	}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	return opts.config
+}
+
+// LaTeXRenderer returns a goldmark node renderer that writes callouts as tcolorbox environments
+// for a goldmark-to-LaTeX renderer. A callout of kind 'note' is written as
+// `\begin{callout-note}{Title}` ... `\end{callout-note}`, with the body left to the renderers
+// of the core nodes. Kinds that are not in the icon set use the generic `callout` environment.
+//
+// The environments are defined by LaTeXPreamble, which must be given the same options.
+func (e *alertCalloutsOptions) LaTeXRenderer(options ...LaTeXOption) renderer.NodeRenderer {
+	return alertRenderer.NewAlertsLaTeXRenderer(e.latexConfig(options))
+}
+
+// LaTeXPreamble returns the preamble that loads tcolorbox (and the symbol font package) and defines
+// a color and an environment for every kind and alias in the icon set of this extension.
+func (e *alertCalloutsOptions) LaTeXPreamble(options ...LaTeXOption) string {
+	return alertRenderer.LaTeXPreamble(e.latexConfig(options))
+}
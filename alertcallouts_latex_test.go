@@ -0,0 +1,59 @@
+package alertcallouts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLaTeXRenderer(t *testing.T) {
+	ext := NewAlertCallouts(UseHybridIcons())
+	result := renderWith(t, ext, ext.LaTeXRenderer(), "> [!warn]- Mind the {gap}\n> Body")
+
+	expected := "\\begin{callout-warn}{Mind the \\{gap\\}}\n\\end{callout-warn}\n"
+	if result != expected {
+		t.Errorf("Expected %q, got %q", expected, result)
+	}
+}
+
+func TestLaTeXPreamble(t *testing.T) {
+	t.Run("Hybrid icon set", func(t *testing.T) {
+		ext := NewAlertCallouts(UseHybridIcons())
+		result := ext.LaTeXPreamble()
+
+		for _, kind := range ext.GetConfig().GetIconKeys() {
+			if !strings.Contains(result, "\\newtcolorbox{callout-"+kind+"}") {
+				t.Errorf("Expected an environment for %q", kind)
+			}
+		}
+		if !strings.Contains(result, "\\usepackage{fontawesome5}") {
+			t.Error("Expected the fontawesome5 package by default")
+		}
+		if !strings.Contains(result, "\\newtcolorbox{callout-hint}[2][\\faLightbulb]") {
+			t.Error("Expected alias 'hint' to use the symbol of 'tip'")
+		}
+	})
+
+	t.Run("Custom symbols and colors", func(t *testing.T) {
+		ext := NewAlertCallouts(UseGFMStrictIcons())
+		result := ext.LaTeXPreamble(
+			WithLaTeXSymbols("bootstrapicons", map[string]string{"note": `\biInfo`}),
+			WithLaTeXColors(map[string]string{"note": "112233"}),
+		)
+
+		if !strings.Contains(result, "\\usepackage{bootstrapicons}") || !strings.Contains(result, "[2][\\biInfo]") {
+			t.Errorf("Expected custom symbol package and command, got:\n%s", result)
+		}
+		if !strings.Contains(result, "\\definecolor{callout-note}{HTML}{112233}") {
+			t.Errorf("Expected custom color, got:\n%s", result)
+		}
+	})
+
+	t.Run("Icons omitted", func(t *testing.T) {
+		ext := NewAlertCallouts(UseGFMStrictIcons())
+		result := ext.LaTeXPreamble(WithLaTeXIcons(LaTeXIconsNone))
+
+		if strings.Contains(result, "fontawesome5") {
+			t.Errorf("Expected no symbol package, got:\n%s", result)
+		}
+	})
+}
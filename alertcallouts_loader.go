@@ -0,0 +1,144 @@
+package alertcallouts
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+
+	utils "github.com/zmtcreative/gm-alert-callouts/internal/utilities"
+)
+
+type iconLoadOptions struct {
+	file      string
+	strict    bool
+	policy    *SVGPolicy
+	processor *SVGProcessor
+}
+
+// newIconLoadOptions returns the loader options, sanitizing icons with DefaultSVGPolicy unless
+// an option says otherwise.
+func newIconLoadOptions(options []IconLoadOption) *iconLoadOptions {
+	opts := &iconLoadOptions{policy: DefaultSVGPolicy()}
+	for _, option := range options {
+		option(opts)
+	}
+	return opts
+}
+
+// finish sanitizes and processes a loaded icon set and applies strict mode.
+func (opts *iconLoadOptions) finish(set *IconSet, diagnostics []Diagnostic) (*IconSet, []Diagnostic, error) {
+	if opts.policy != nil {
+		var removed []Diagnostic
+		set, removed = set.Sanitized(opts.policy)
+		diagnostics = append(diagnostics, removed...)
+		utils.SortDiagnostics(diagnostics)
+	}
+	if opts.strict && utils.HasWarnings(diagnostics) {
+		return nil, diagnostics, &IconSetError{Diagnostics: diagnostics}
+	}
+	if opts.processor != nil {
+		set = set.Processed(opts.processor)
+	}
+	return set, diagnostics, nil
+}
+
+// IconLoadOption is a functional option for configuring the icon set loader.
+type IconLoadOption func(*iconLoadOptions)
+
+// WithIconFileName sets the file name reported in the diagnostics of the loader.
+func WithIconFileName(name string) IconLoadOption {
+	return func(opts *iconLoadOptions) {
+		opts.file = name
+	}
+}
+
+// WithStrictIcons sets whether the loader fails when any line is skipped or looks wrong,
+// i.e. when there is a diagnostic with SeverityWarning.
+func WithStrictIcons(strict bool) IconLoadOption {
+	return func(opts *iconLoadOptions) {
+		opts.strict = strict
+	}
+}
+
+// WithIconSanitizer sets the policy used to sanitize the loaded icons, or nil to load them
+// verbatim. The loader uses DefaultSVGPolicy by default and reports every removal as a diagnostic.
+func WithIconSanitizer(policy *SVGPolicy) IconLoadOption {
+	return func(opts *iconLoadOptions) {
+		opts.policy = policy
+	}
+}
+
+// WithIconProcessor sets a processor applied to the loaded icons after sanitizing, so the icon
+// set holds the processed icons and rendering it needs no further work.
+func WithIconProcessor(processor *SVGProcessor) IconLoadOption {
+	return func(opts *iconLoadOptions) {
+		opts.processor = processor
+	}
+}
+
+// IconSetError is returned by the loader in strict mode when the icon data has warnings.
+type IconSetError struct {
+	Diagnostics []Diagnostic // Every diagnostic of the icon data, including the informational ones
+}
+
+func (e *IconSetError) Error() string {
+	var warnings []Diagnostic
+	for _, d := range e.Diagnostics {
+		if d.Severity == SeverityWarning {
+			warnings = append(warnings, d)
+		}
+	}
+	if len(warnings) == 1 {
+		return "icon set has a warning: " + warnings[0].String()
+	}
+	return fmt.Sprintf("icon set has %d warnings, the first: %s", len(warnings), warnings[0])
+}
+
+// ParseIconSet reads icon data in the .icons format and reports every line that is skipped or
+// looks wrong as a Diagnostic with its file, line and column: malformed lines, invalid or reserved
+// kinds, aliases that cannot be resolved, collisions and invalid fields. The icon set holds what
+// LoadIconSet and CreateIconsMap would load from the same data, with the icons sanitized (see
+// WithIconSanitizer). In strict mode (WithStrictIcons) any warning makes it return an
+// *IconSetError instead of the icon set.
+func ParseIconSet(r io.Reader, options ...IconLoadOption) (*IconSet, []Diagnostic, error) {
+	opts := newIconLoadOptions(options)
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return opts.finish(utils.ParseIconSetDiagnostics(string(data), opts.file))
+}
+
+// LoadIconSetFile reads an .icons file like ParseIconSet, reporting diagnostics with the path as
+// file name unless WithIconFileName sets another one.
+func LoadIconSetFile(path string, options ...IconLoadOption) (*IconSet, []Diagnostic, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	return ParseIconSet(f, append([]IconLoadOption{WithIconFileName(path)}, options...)...)
+}
+
+// IconManifestFile is the name of the optional manifest in an icon directory read by
+// LoadIconSetFS. It uses the .icons format, typically for aliases and fields.
+const IconManifestFile = utils.IconManifestFile
+
+// LoadIconSetFS builds an icon set from the .svg files in dir of fsys, which can be an embed.FS,
+// os.DirFS or fstest.MapFS. The file name without its extension is the kind (note.svg defines
+// 'note'), and each file is collapsed to a single line: the XML prolog, DOCTYPE, comments and the
+// whitespace between tags are removed. Aliases and fields come from the optional manifest
+// (IconManifestFile) in the same directory. Diagnostics name the files they refer to; an error is
+// returned when dir cannot be read, or in strict mode (WithStrictIcons) when there are warnings.
+func LoadIconSetFS(fsys fs.FS, dir string, options ...IconLoadOption) (*IconSet, []Diagnostic, error) {
+	opts := newIconLoadOptions(options)
+
+	set, diagnostics, err := utils.ParseIconSetFS(fsys, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	return opts.finish(set, diagnostics)
+}
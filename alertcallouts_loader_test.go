@@ -0,0 +1,113 @@
+package alertcallouts
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+const loaderTestData = `# Icons
+note|<svg>note</svg>
+  my@icon|<svg/>
+noicon-tip|<svg/>
+warning <svg>warning</svg>
+empty|
+info->note
+lost->missing
+note.color=blue
+note.shape=round
+ghost.title=Boo
+`
+
+func TestParseIconSet(t *testing.T) {
+	t.Run("Reports every skipped or suspicious line", func(t *testing.T) {
+		set, diagnostics, err := ParseIconSet(strings.NewReader(loaderTestData), WithIconFileName("brand.icons"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var got []string
+		for _, d := range diagnostics {
+			got = append(got, d.String())
+		}
+		expected := []string{
+			`brand.icons:3:3: warning: icon skipped: invalid kind "my@icon": kinds start with a letter and contain only letters, numbers, '_' and '-'`,
+			`brand.icons:4:1: warning: icon skipped: kind "noicon-tip" uses the reserved prefix "noicon-"`,
+			`brand.icons:5:1: warning: line skipped: expected 'kind|icon', 'alias->kind', 'kind.field=value' or a '#' comment`,
+			`brand.icons:6:7: warning: kind "empty" has an empty icon`,
+			`brand.icons:8:7: warning: alias "lost" refers to unknown kind "missing"`,
+			`brand.icons:9:1: warning: field "color" skipped: "blue" is not a six-digit hex color`,
+			`brand.icons:10:1: warning: field "shape" skipped: unknown field`,
+			`brand.icons:11:1: warning: field "title" skipped: kind "ghost" is not defined`,
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("Expected:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+		}
+		if !reflect.DeepEqual(set.Icons(), CreateIconsMap(loaderTestData)) {
+			t.Errorf("Expected the same icons as CreateIconsMap, got %v", set.Icons())
+		}
+	})
+
+	t.Run("Clean data has no diagnostics", func(t *testing.T) {
+		_, diagnostics, err := ParseIconSet(strings.NewReader(alertCalloutsIconsObsidian), WithStrictIcons(true))
+		if err != nil || len(diagnostics) != 0 {
+			t.Errorf("Expected the built-in icons to load cleanly, got %v %v", err, diagnostics)
+		}
+	})
+
+	t.Run("Strict mode fails on warnings", func(t *testing.T) {
+		set, diagnostics, err := ParseIconSet(strings.NewReader(loaderTestData), WithStrictIcons(true))
+		var setErr *IconSetError
+		if set != nil || !errors.As(err, &setErr) || len(setErr.Diagnostics) != len(diagnostics) {
+			t.Fatalf("Expected an IconSetError and no icon set, got %v %v", set, err)
+		}
+		if !strings.HasPrefix(err.Error(), "icon set has 8 warnings, the first: <icons>:3:3: warning:") {
+			t.Errorf("Unexpected error message: %v", err)
+		}
+	})
+
+	t.Run("Loads files with their path in diagnostics", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "custom.icons")
+		if err := os.WriteFile(path, []byte("note|<svg/>\nbad line\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		_, diagnostics, err := LoadIconSetFile(path)
+		if err != nil || len(diagnostics) != 1 || diagnostics[0].File != path || diagnostics[0].Line != 2 {
+			t.Errorf("Unexpected result: %v %v", diagnostics, err)
+		}
+		if _, _, err := LoadIconSetFile(filepath.Join(t.TempDir(), "missing.icons")); err == nil {
+			t.Error("Expected an error for a missing file")
+		}
+	})
+}
+
+func TestLoadIconSetFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"brand/note.svg":       {Data: []byte("<?xml version=\"1.0\"?>\n<!-- exported -->\n<svg viewBox=\"0 0 24 24\">\n  <circle r=\"4\"/>\n</svg>\n")},
+		"brand/rocket.svg":     {Data: []byte("<svg><path d=\"M1 1\"/></svg>")},
+		"brand/manifest.icons": {Data: []byte("info->note\nlaunch->rocket\nrocket.title=Shipped\n")},
+	}
+
+	t.Run("Plugs into WithIcons", func(t *testing.T) {
+		set, diagnostics, err := LoadIconSetFS(fsys, "brand")
+		if err != nil || len(diagnostics) != 0 {
+			t.Fatalf("Unexpected result: %v %v", diagnostics, err)
+		}
+		ext := NewAlertCallouts(WithIcons(set.Icons()), WithIconAliases(set.Aliases()))
+		result := convertWith(t, ext, "> [!info]\n> a\n\n> [!launch]\n> b\n")
+		if !strings.Contains(result, `<svg viewBox="0 0 24 24"><circle r="4"/></svg>`) || !strings.Contains(result, `<svg><path d="M1 1"/></svg>`) {
+			t.Errorf("Expected the collapsed icons, got:\n%s", result)
+		}
+	})
+
+	t.Run("Strict mode", func(t *testing.T) {
+		broken := fstest.MapFS{"a.svg": {Data: []byte("oops")}}
+		if _, _, err := LoadIconSetFS(broken, ".", WithStrictIcons(true)); err == nil {
+			t.Error("Expected an error in strict mode")
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package alertcallouts
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -288,3 +289,132 @@ info -> note`
 		}
 	})
 }
+
+func TestColorAndEmojiOptions(t *testing.T) {
+	colors := map[string]string{"note": "0969DA"}
+	emoji := map[string]string{"note": "📝"}
+
+	ext := NewAlertCallouts(UseGFMStrictIcons(), WithColors(colors), WithEmoji(emoji))
+	config := ext.GetConfig()
+
+	if config.Colors["note"] != "0969DA" {
+		t.Errorf("Expected note color to be set, got %q", config.Colors["note"])
+	}
+	if config.Emoji["note"] != "📝" {
+		t.Errorf("Expected note emoji to be set, got %q", config.Emoji["note"])
+	}
+
+	result := renderWith(t, ext, ext.TextRenderer(WithTextEmoji(true)), "> [!NOTE]\n> Body")
+	if result != "📝 NOTE\n    Body\n" {
+		t.Errorf("Expected the text renderer to use the extension emoji, got %q", result)
+	}
+}
+
+func TestIconSetOption(t *testing.T) {
+	set := LoadIconSet(`note|<svg>note</svg>
+info->note
+note.title=Good to know
+note.color=#123456
+info.title=FYI & more`)
+
+	t.Run("Sets icons, aliases, titles and colors", func(t *testing.T) {
+		ext := NewAlertCallouts(WithIconSet(set))
+		config := ext.GetConfig()
+
+		if len(config.Icons) != 2 || config.Aliases["info"] != "note" {
+			t.Errorf("Unexpected icons or aliases: %v %v", config.Icons, config.Aliases)
+		}
+		if config.Colors["note"] != "123456" || config.Colors["tip"] != "1A7F37" {
+			t.Errorf("Expected the icon set colors merged into the built-in colors, got %v", config.Colors)
+		}
+	})
+
+	t.Run("Default titles are rendered when the markdown has none", func(t *testing.T) {
+		ext := NewAlertCallouts(WithIconSet(set))
+		result := convertWith(t, ext, "> [!note]\n> a\n\n> [!info]\n> b\n\n> [!note] Custom\n> c\n")
+
+		for _, expected := range []string{
+			`<p class="callout-title-text">Good to know</p>`,
+			`<p class="callout-title-text">FYI &amp; more</p>`,
+			`<p class="callout-title-text">Custom</p>`,
+		} {
+			if !strings.Contains(result, expected) {
+				t.Errorf("Expected %q in:\n%s", expected, result)
+			}
+		}
+	})
+
+	t.Run("WithIcons clears the icon set data", func(t *testing.T) {
+		config := NewAlertCallouts(WithIconSet(set), WithIcons(map[string]string{"tip": "<svg/>"})).GetConfig()
+		if config.Aliases != nil || config.Titles != nil || config.FoldStates != nil || config.GFMKinds != nil {
+			t.Errorf("Expected aliases and titles to be cleared, got %v %v", config.Aliases, config.Titles)
+		}
+	})
+
+	t.Run("Default fold states", func(t *testing.T) {
+		folded := LoadIconSet("note|<svg>note</svg>\ninfo->note\ntip|<svg>tip</svg>\nnote.fold=closed\ntip.fold=open\n")
+		source := "> [!info]\n> a\n\n> [!tip]\n> b\n\n> [!note]+\n> c\n"
+
+		result := convertWith(t, NewAlertCallouts(WithIconSet(folded)), source)
+		for _, expected := range []string{
+			`<details class="callout callout-foldable callout-info" data-callout="info">`,
+			`<details class="callout callout-foldable callout-tip" data-callout="tip" open>`,
+			`<details class="callout callout-foldable callout-note" data-callout="note" open>`,
+		} {
+			if !strings.Contains(result, expected) {
+				t.Errorf("Expected %q in:\n%s", expected, result)
+			}
+		}
+
+		if result := convertWith(t, NewAlertCallouts(WithIconSet(folded), WithFolding(false)), source); strings.Contains(result, "<details") {
+			t.Errorf("Expected no foldable callouts without folding, got:\n%s", result)
+		}
+	})
+
+	t.Run("GFM kinds place custom kinds in GFM-only outputs", func(t *testing.T) {
+		custom := LoadIconSet("note|<svg>note</svg>\nsecurity|<svg>lock</svg>\nsecurity.gfm=caution\n")
+		ext := NewAlertCallouts(WithIconSet(custom))
+		if result := renderWith(t, ext, ext.DocBookRenderer(), "> [!security]\n> a\n"); !strings.HasPrefix(result, `<caution role="security">`) {
+			t.Errorf("Expected security to be written as a caution, got %q", result)
+		}
+	})
+
+	t.Run("Built-in icon sets carry GFM fallback kinds", func(t *testing.T) {
+		def, ok := LoadIconSet(alertCalloutsIconsObsidian).Definition("error")
+		if !ok || def.Primary != "danger" || def.GFMKind != "caution" {
+			t.Errorf("Unexpected definition for 'error': %+v", def)
+		}
+	})
+}
+
+func TestCanonicalKindsOption(t *testing.T) {
+	source := "> [!info]\n> a\n"
+
+	result := convertWith(t, NewAlertCallouts(UseHybridIcons()), source)
+	if !strings.Contains(result, `<div class="callout callout-info iconset-hybrid" data-callout="info">`) {
+		t.Errorf("Expected the alias as written by default, got:\n%s", result)
+	}
+
+	result = convertWith(t, NewAlertCallouts(UseHybridIcons(), WithCanonicalKinds(true)), source)
+	if !strings.Contains(result, `<div class="callout callout-note iconset-hybrid" data-callout="note" data-callout-alias="info">`) {
+		t.Errorf("Expected the canonical kind, got:\n%s", result)
+	}
+	if !strings.Contains(result, `<p class="callout-title-text">Info</p>`) {
+		t.Errorf("Expected the alias as the default title, got:\n%s", result)
+	}
+}
+
+func TestMergeIconSetsFunction(t *testing.T) {
+	base := LoadIconSet("note|<svg>note</svg>\ntip|<svg>tip</svg>\n")
+	brand := LoadIconSet("tip|<svg>brand</svg>\nhint->tip2\ntip2->tip\n")
+
+	merged, diagnostics := MergeIconSets(base, brand)
+
+	if len(diagnostics) != 1 || diagnostics[0].Severity != SeverityInfo || diagnostics[0].Kind != "tip" || diagnostics[0].Line != 2 {
+		t.Errorf("Expected one override of tip on line 2, got %v", diagnostics)
+	}
+	config := NewAlertCallouts(WithIconSet(merged)).GetConfig()
+	if config.Icons["hint"] != "<svg>brand</svg>" || config.Aliases["hint"] != "tip" {
+		t.Errorf("Expected hint to resolve through tip2 to the brand tip, got %q %q", config.Icons["hint"], config.Aliases["hint"])
+	}
+}
@@ -0,0 +1,21 @@
+package alertcallouts
+
+import (
+	utils "github.com/zmtcreative/gm-alert-callouts/internal/utilities"
+)
+
+// SVGProcessor rewrites icons before they are rendered: it sets or removes attributes of the root
+// <svg> element (such as class, width, height or aria-hidden) and optionally minifies the markup
+// by dropping comments, whitespace between tags and default attributes. Results are cached, so
+// each icon is processed once however many kinds, icon sets or extensions use it.
+type SVGProcessor = utils.SVGProcessor
+
+// WithSVGProcessor sets a processor applied to every icon, built-in ones included, when the
+// extension is added to goldmark (and by IconSprite, IconStylesheet and Stylesheet). Icons that
+// are not built in are sanitized first, so the processor can add attributes the sanitizer would
+// remove. The renderers write the processed icons as they are, without any per-render work.
+func WithSVGProcessor(processor *SVGProcessor) Option {
+	return func(opts *alertCalloutsOptions) {
+		opts.config.SVGProcessor = processor
+	}
+}
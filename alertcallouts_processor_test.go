@@ -0,0 +1,54 @@
+package alertcallouts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSVGProcessor(t *testing.T) {
+	processor := func() *SVGProcessor {
+		return &SVGProcessor{
+			Attributes:       map[string]string{"class": "callout-icon", "aria-hidden": "true", "width": "16", "height": "16"},
+			RemoveAttributes: []string{"stroke-width"},
+		}
+	}
+
+	t.Run("Built-in icons are processed", func(t *testing.T) {
+		result := convertWith(t, NewAlertCallouts(UseHybridIcons(), WithSVGProcessor(processor())), "> [!note]\n> a\n")
+		if !strings.Contains(result, `<svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="none" stroke="currentColor" stroke-linecap="round" stroke-linejoin="round" class="callout-icon" aria-hidden="true">`) {
+			t.Errorf("Expected a processed icon, got:\n%s", result)
+		}
+	})
+
+	t.Run("Custom icons are sanitized before processing", func(t *testing.T) {
+		ext := NewAlertCallouts(
+			WithIcon("note", `<svg onload="alert(1)"><!-- x --> <path d="M1 1"/> </svg>`),
+			WithSVGProcessor(&SVGProcessor{Attributes: map[string]string{"class": "callout-icon"}, Minify: true}),
+		)
+		result := convertWith(t, ext, "> [!note]\n> a\n")
+		if !strings.Contains(result, `<svg class="callout-icon"><path d="M1 1"/></svg>`) {
+			t.Errorf("Expected a sanitized and minified icon, got:\n%s", result)
+		}
+	})
+
+	t.Run("Configured icons are not modified", func(t *testing.T) {
+		ext := NewAlertCallouts(UseHybridIcons(), WithSVGProcessor(processor()))
+		if icon := ext.GetConfig().Icons["note"]; strings.Contains(icon, "callout-icon") {
+			t.Errorf("Expected the configured icon unchanged, got %q", icon)
+		}
+		if css := ext.IconStylesheet(); !strings.Contains(css, "callout-icon") {
+			t.Errorf("Expected the icon stylesheet to use the processed icons")
+		}
+	})
+
+	t.Run("Loader", func(t *testing.T) {
+		set, _, err := ParseIconSet(strings.NewReader("note|<svg width=\"24\"></svg>\ninfo->note\n"), WithIconProcessor(processor()))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := `<svg width="16" aria-hidden="true" class="callout-icon" height="16"></svg>`
+		if set.Icons()["note"] != expected || set.Icons()["info"] != expected {
+			t.Errorf("Expected processed icons, got %v", set.Icons())
+		}
+	})
+}
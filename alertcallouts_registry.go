@@ -0,0 +1,129 @@
+package alertcallouts
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/zmtcreative/gm-alert-callouts/internal/constants"
+	utils "github.com/zmtcreative/gm-alert-callouts/internal/utilities"
+)
+
+// presets maps the names of the built-in icon sets to their options, in the order Presets lists them.
+var presets = []struct {
+	name   string
+	option func() Option
+	data   string
+}{
+	{"gfm", UseGFMStrictIcons, alertCalloutsIconsGFMStrict},
+	{"hybrid", UseHybridIcons, alertCalloutsIconsHybrid},
+	{"obsidian", UseObsidianIcons, alertCalloutsIconsObsidian},
+	{"emoji", UseEmojiIcons, alertCalloutsIconsEmoji},
+}
+
+var (
+	iconSetsMu sync.RWMutex
+	iconSets   = make(map[string]*IconSet)
+
+	iconSetNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+)
+
+// RegisterIconSet makes an icon set available under name for UseIconSet, so applications and
+// configuration files can select icon sets by name. The set can come from LoadIconSet (a string),
+// LoadIconSetFile or LoadIconSetFS. Names are lower-cased, must start with a letter and may only
+// contain letters, digits, dashes and underscores, since they become the iconset-<name> class.
+// Registering a name again replaces the icon set; the names of the presets cannot be registered.
+func RegisterIconSet(name string, set *IconSet) error {
+	name = strings.ToLower(name)
+	if !iconSetNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid icon set name %q: use a letter followed by letters, digits, '-' or '_'", name)
+	}
+	if slices.Contains(Presets(), name) {
+		return fmt.Errorf("icon set name %q is reserved for a built-in icon set", name)
+	}
+	if set == nil {
+		return fmt.Errorf("icon set %q is nil", name)
+	}
+
+	iconSetsMu.Lock()
+	defer iconSetsMu.Unlock()
+	iconSets[name] = set
+	return nil
+}
+
+// Presets returns the names of the built-in icon sets: gfm, hybrid, obsidian and emoji.
+func Presets() []string {
+	names := make([]string, len(presets))
+	for i, preset := range presets {
+		names[i] = preset.name
+	}
+	return names
+}
+
+// IconSets returns the names of every icon set UseIconSet accepts: the presets followed by the
+// registered icon sets in name order.
+func IconSets() []string {
+	iconSetsMu.RLock()
+	defer iconSetsMu.RUnlock()
+
+	var registered []string
+	for name := range iconSets {
+		registered = append(registered, name)
+	}
+	slices.Sort(registered)
+	return append(Presets(), registered...)
+}
+
+// LookupIconSet returns the icon set with the given name, a preset or a registered icon set, for
+// instance to list its kinds with Primaries, AliasesOf and Groups. It reports false for unknown names.
+func LookupIconSet(name string) (*IconSet, bool) {
+	name = strings.ToLower(name)
+	for _, preset := range presets {
+		if preset.name == name {
+			return utils.ParseIconSet(preset.data), true
+		}
+	}
+
+	iconSetsMu.RLock()
+	defer iconSetsMu.RUnlock()
+	set, ok := iconSets[name]
+	return set, ok
+}
+
+// CheckIconSet returns an error naming the accepted icon sets when UseIconSet does not know name,
+// so names from user input or configuration files can be checked before they are used.
+func CheckIconSet(name string) error {
+	if sets := IconSets(); !slices.Contains(sets, strings.ToLower(name)) {
+		return fmt.Errorf("unknown icon set %q: use one of %s", name, strings.Join(sets, ", "))
+	}
+	return nil
+}
+
+// UseIconSet selects an icon set by name: a preset (the same as UseGFMStrictIcons, UseHybridIcons,
+// UseObsidianIcons or UseEmojiIcons) or an icon set added with RegisterIconSet. A registered icon
+// set is applied as with WithIconSet, keeping the folding and custom alert settings, and callouts
+// get the iconset-<name> class instead of the class of a preset. An unknown name leaves the
+// configuration unchanged; check names from user input with CheckIconSet first.
+func UseIconSet(name string) Option {
+	return func(opts *alertCalloutsOptions) {
+		name := strings.ToLower(name)
+		for _, preset := range presets {
+			if preset.name == name {
+				preset.option()(opts)
+				return
+			}
+		}
+
+		iconSetsMu.RLock()
+		set, ok := iconSets[name]
+		iconSetsMu.RUnlock()
+		if !ok {
+			return
+		}
+		WithIconSet(set)(opts)
+		opts.config.DefaultIcons = constants.ICONS_NONE
+		opts.config.IconSet = name
+	}
+}
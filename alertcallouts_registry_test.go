@@ -0,0 +1,95 @@
+package alertcallouts
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestIconSetRegistry(t *testing.T) {
+	brand := LoadIconSet("note|<svg>brand note</svg>\ninfo->note\n")
+	if err := RegisterIconSet("Brand", brand); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	fsys := fstest.MapFS{"icons/rocket.svg": {Data: []byte("<svg>rocket</svg>")}}
+	fromFS, _, err := LoadIconSetFS(fsys, "icons")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := RegisterIconSet("space-theme", fromFS); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	t.Cleanup(func() {
+		iconSetsMu.Lock()
+		delete(iconSets, "brand")
+		delete(iconSets, "space-theme")
+		iconSetsMu.Unlock()
+	})
+
+	t.Run("Invalid and reserved names", func(t *testing.T) {
+		for _, name := range []string{"", "1st", "my brand", "brand.v2", "hybrid", "GFM"} {
+			if err := RegisterIconSet(name, brand); err == nil {
+				t.Errorf("Expected an error for %q", name)
+			}
+		}
+		if err := RegisterIconSet("empty", nil); err == nil {
+			t.Error("Expected an error for a nil icon set")
+		}
+	})
+
+	t.Run("Listing", func(t *testing.T) {
+		if presets := Presets(); !reflect.DeepEqual(presets, []string{"gfm", "hybrid", "obsidian", "emoji"}) {
+			t.Errorf("Unexpected presets: %v", presets)
+		}
+		if sets := IconSets(); !reflect.DeepEqual(sets, []string{"gfm", "hybrid", "obsidian", "emoji", "brand", "space-theme"}) {
+			t.Errorf("Unexpected icon sets: %v", sets)
+		}
+	})
+
+	t.Run("Registered icon set", func(t *testing.T) {
+		ext := NewAlertCallouts(UseHybridIcons(), UseIconSet("brand"))
+		result := convertWith(t, ext, "> [!info]\n> a\n")
+		if !strings.Contains(result, `<div class="callout callout-info iconset-brand" data-callout="info">`) || !strings.Contains(result, "<svg>brand note</svg>") {
+			t.Errorf("Expected the brand icon set, got:\n%s", result)
+		}
+		if !ext.GetConfig().FoldingEnabled || !ext.GetConfig().CustomAlertsEnabled {
+			t.Error("Expected the other settings to be kept")
+		}
+
+		result = convertWith(t, NewAlertCallouts(UseIconSet("SPACE-THEME")), "> [!rocket]\n> a\n")
+		if !strings.Contains(result, "iconset-space-theme") || !strings.Contains(result, "<svg>rocket</svg>") {
+			t.Errorf("Expected the icon set from the file system, got:\n%s", result)
+		}
+	})
+
+	t.Run("Presets by name", func(t *testing.T) {
+		byName := convertWith(t, NewAlertCallouts(UseIconSet("obsidian")), "> [!tip]\n> a\n")
+		byOption := convertWith(t, NewAlertCallouts(UseObsidianIcons()), "> [!tip]\n> a\n")
+		if byName != byOption || !strings.Contains(byName, "iconset-obsidian") {
+			t.Errorf("Expected the same output as UseObsidianIcons, got:\n%s\n%s", byName, byOption)
+		}
+
+		result := convertWith(t, NewAlertCallouts(UseIconSet("brand"), UseHybridIcons()), "> [!note]\n> a\n")
+		if !strings.Contains(result, "iconset-hybrid") || strings.Contains(result, "iconset-brand") {
+			t.Errorf("Expected a later preset to replace the icon set name, got:\n%s", result)
+		}
+	})
+
+	t.Run("Unknown name", func(t *testing.T) {
+		ext := NewAlertCallouts(UseGFMStrictIcons(), UseIconSet("missing"))
+		if ext.GetConfig().IconSet != "gfm" || len(ext.GetConfig().Icons) != 5 {
+			t.Errorf("Expected the configuration to be unchanged, got %q", ext.GetConfig().IconSet)
+		}
+
+		err := CheckIconSet("missing")
+		if err == nil || !strings.Contains(err.Error(), `"missing"`) || !strings.Contains(err.Error(), "gfm, hybrid, obsidian, emoji, brand, space-theme") {
+			t.Errorf("Expected an error listing the icon sets, got %v", err)
+		}
+		for _, name := range []string{"hybrid", "Brand", "space-theme"} {
+			if err := CheckIconSet(name); err != nil {
+				t.Errorf("Expected no error for %q, got %v", name, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package alertcallouts
+
+import (
+	"github.com/yuin/goldmark/renderer"
+
+	alertRenderer "github.com/zmtcreative/gm-alert-callouts/internal/renderer"
+)
+
+type rstOptions struct {
+	config alertRenderer.RSTConfig
+}
+
+// RSTOption is a functional option for configuring the reStructuredText renderer.
+type RSTOption func(*rstOptions)
+
+// WithRSTDirectives sets the admonition directive used for each alert kind, replacing the default
+// mapping of the docutils admonitions (attention, caution, danger, error, hint, important, note,
+// tip and warning). An alias that has no directive of its own uses the directive of its primary kind.
+func WithRSTDirectives(directives map[string]string) RSTOption {
+	return func(opts *rstOptions) {
+		opts.config.Directives = directives
+	}
+}
+
+// WithRSTFallback sets the directive used for kinds that have no mapping ("note" by default).
+func WithRSTFallback(directive string) RSTOption {
+	return func(opts *rstOptions) {
+		opts.config.Fallback = directive
+	}
+}
+
+// WithRSTCollapsible sets whether folded callouts get Sphinx's `:collapsible:` option
+// (`open` for `[!kind]+`, `closed` for `[!kind]-`).
+func WithRSTCollapsible(enable bool) RSTOption {
+	return func(opts *rstOptions) {
+		opts.config.Collapsible = enable
+	}
+}
+
+// WithRSTIndent sets the number of spaces the directive body is indented by (3 by default).
+func WithRSTIndent(spaces int) RSTOption {
+	return func(opts *rstOptions) {
+		opts.config.Indent = spaces
+	}
+}
+
+// WithRSTWidth sets the column that paragraphs in the directive body are wrapped at. A width of 0 disables wrapping.
+func WithRSTWidth(width int) RSTOption {
+	return func(opts *rstOptions) {
+		opts.config.Width = width
+	}
+}
+
+// RSTRenderer returns a goldmark node renderer that writes callouts as reStructuredText admonition
+// directives (`.. note:: Title`) with an indented body. Aliases are resolved through the icon set of
+// this extension and any kind without a mapping uses the fallback directive; whenever the directive
+// differs from the kind, the kind is kept in a `:class:` option.
+func (e *alertCalloutsOptions) RSTRenderer(options ...RSTOption) renderer.NodeRenderer {
+	opts := &rstOptions{
+		config: alertRenderer.RSTConfig{
+			Aliases:        e.config.Aliases,
+			GFMKinds:       e.config.GFMKinds,
+			FoldingEnabled: e.config.FoldingEnabled,
+			Directives:     alertRenderer.RSTDirectives,
+			Fallback:       "note",
+			Collapsible:    true,
+			Indent:         3,
+		},
+	}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	return alertRenderer.NewAlertsRSTRenderer(opts.config)
+}
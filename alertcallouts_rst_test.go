@@ -0,0 +1,44 @@
+package alertcallouts
+
+import (
+	"testing"
+)
+
+func TestRSTRenderer(t *testing.T) {
+	t.Run("Obsidian folded callout is collapsible", func(t *testing.T) {
+		ext := NewAlertCallouts(UseObsidianIcons())
+		result := renderWith(t, ext, ext.RSTRenderer(), "> [!faq]- Why?\n> Because")
+
+		// faq is an alias of question, whose GFM kind is important.
+		expected := ".. important:: Why?\n   :class: faq\n   :collapsible: closed\n\n   Because\n"
+		if result != expected {
+			t.Errorf("Expected %q, got %q", expected, result)
+		}
+	})
+
+	t.Run("Collapsible disabled", func(t *testing.T) {
+		ext := NewAlertCallouts(UseHybridIcons())
+		result := renderWith(t, ext, ext.RSTRenderer(WithRSTCollapsible(false)), "> [!hint]-\n> Body")
+
+		expected := ".. hint::\n\n   Body\n"
+		if result != expected {
+			t.Errorf("Expected %q, got %q", expected, result)
+		}
+	})
+
+	t.Run("Custom directives, indent and width", func(t *testing.T) {
+		ext := NewAlertCallouts(UseGFMStrictIcons())
+		nr := ext.RSTRenderer(
+			WithRSTDirectives(map[string]string{"note": "seealso"}),
+			WithRSTFallback("admonition"),
+			WithRSTIndent(4),
+			WithRSTWidth(14),
+		)
+		result := renderWith(t, ext, nr, "> [!NOTE]\n> alpha beta gamma")
+
+		expected := ".. seealso::\n    :class: note\n\n    alpha beta\n    gamma\n"
+		if result != expected {
+			t.Errorf("Expected %q, got %q", expected, result)
+		}
+	})
+}
@@ -0,0 +1,61 @@
+package alertcallouts
+
+import (
+	"sync"
+
+	utils "github.com/zmtcreative/gm-alert-callouts/internal/utilities"
+)
+
+// SVGPolicy lists the elements and attributes the SVG sanitizer keeps. Event handler attributes,
+// script URLs and references to external resources are removed whatever the policy says.
+type SVGPolicy = utils.SVGPolicy
+
+// DefaultSVGPolicy returns the sanitizer policy used by default: it keeps the shapes, text,
+// gradients and presentation attributes icons are made of, and removes everything that can run
+// script or load content, such as <script>, <foreignObject>, <style>, <image> and <a>.
+func DefaultSVGPolicy() *SVGPolicy {
+	return utils.DefaultSVGPolicy()
+}
+
+// WithSVGSanitizer sets the policy used to sanitize icons before they are written into the HTML.
+// Icons from WithIcon, WithIcons, WithIconSet and the loaders are sanitized with DefaultSVGPolicy
+// unless this option sets another policy, or nil to write them verbatim. The icons of the built-in
+// icon sets are trusted and written as they are.
+func WithSVGSanitizer(policy *SVGPolicy) Option {
+	return func(opts *alertCalloutsOptions) {
+		opts.config.SVGPolicy = policy
+	}
+}
+
+// embeddedIcons holds every icon of the built-in icon sets, which need no sanitizing.
+var embeddedIcons = sync.OnceValue(func() map[string]bool {
+	icons := make(map[string]bool)
+	for _, data := range []string{alertCalloutsIconsGFMStrict, alertCalloutsIconsHybrid, alertCalloutsIconsObsidian, alertCalloutsIconsEmoji} {
+		for _, icon := range utils.CreateIconsMap(data) {
+			icons[icon] = true
+		}
+	}
+	return icons
+})
+
+// renderIcons returns the icons to write into the HTML: the configured icons, with every icon
+// that is not built in passed through the SVG sanitizer (unless it is disabled), and every icon
+// passed through the SVG processor when there is one. The configured map is never modified.
+func (e *alertCalloutsOptions) renderIcons() map[string]string {
+	policy := e.config.SVGPolicy
+	processor := e.config.SVGProcessor
+	if policy == nil && processor == nil {
+		return e.config.Icons
+	}
+	icons := make(map[string]string, len(e.config.Icons))
+	for kind, icon := range e.config.Icons {
+		if policy != nil && !embeddedIcons()[icon] {
+			icon, _ = policy.Sanitize(icon)
+		}
+		if processor != nil {
+			icon = processor.Process(icon)
+		}
+		icons[kind] = icon
+	}
+	return icons
+}
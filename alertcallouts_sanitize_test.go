@@ -0,0 +1,77 @@
+package alertcallouts
+
+import (
+	"strings"
+	"testing"
+)
+
+const maliciousIcon = `<svg viewBox="0 0 24 24" onload="alert(1)"><script>alert(2)</script><path d="M1 1"/></svg>`
+
+func TestSVGSanitizer(t *testing.T) {
+	source := "> [!note]\n> a\n"
+
+	t.Run("Custom icons are sanitized by default", func(t *testing.T) {
+		ext := NewAlertCallouts(WithIcon("note", maliciousIcon))
+		result := convertWith(t, ext, source)
+		if !strings.Contains(result, `<svg viewBox="0 0 24 24"><path d="M1 1"/></svg>`) || strings.Contains(result, "alert(") {
+			t.Errorf("Expected the icon to be sanitized, got:\n%s", result)
+		}
+		if ext.GetConfig().Icons["note"] != maliciousIcon {
+			t.Error("Expected the configured icon map to be left alone")
+		}
+	})
+
+	t.Run("Sprites and icon stylesheets are sanitized too", func(t *testing.T) {
+		ext := NewAlertCallouts(WithIcon("note", maliciousIcon), WithIconMode(IconsSprite))
+		if sprite := ext.IconSprite(); strings.Contains(sprite, "alert(") {
+			t.Errorf("Expected a sanitized sprite, got:\n%s", sprite)
+		}
+		if css := ext.IconStylesheet(); strings.Contains(css, "alert") {
+			t.Errorf("Expected a sanitized icon stylesheet, got:\n%s", css)
+		}
+	})
+
+	t.Run("Sanitizer can be disabled", func(t *testing.T) {
+		ext := NewAlertCallouts(WithIcon("note", maliciousIcon), WithSVGSanitizer(nil))
+		if result := convertWith(t, ext, source); !strings.Contains(result, maliciousIcon) {
+			t.Errorf("Expected the icon verbatim, got:\n%s", result)
+		}
+	})
+
+	t.Run("Custom policy", func(t *testing.T) {
+		policy := &SVGPolicy{Elements: []string{"svg"}, Attributes: []string{"viewbox"}}
+		ext := NewAlertCallouts(WithIcon("note", maliciousIcon), WithSVGSanitizer(policy))
+		if result := convertWith(t, ext, source); !strings.Contains(result, `<svg viewBox="0 0 24 24"></svg>`) {
+			t.Errorf("Expected only the allowed markup, got:\n%s", result)
+		}
+	})
+
+	t.Run("Built-in icons are written as they are", func(t *testing.T) {
+		sanitized := convertWith(t, NewAlertCallouts(UseObsidianIcons()), source)
+		verbatim := convertWith(t, NewAlertCallouts(UseObsidianIcons(), WithSVGSanitizer(nil)), source)
+		if sanitized != verbatim {
+			t.Errorf("Expected identical output, got:\n%s\n%s", sanitized, verbatim)
+		}
+	})
+
+	t.Run("Loaders report what they strip", func(t *testing.T) {
+		set, diagnostics, err := ParseIconSet(strings.NewReader("note|"+maliciousIcon+"\n"), WithIconFileName("upload.icons"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(diagnostics) != 2 || diagnostics[0].String() != `upload.icons:1:1: warning: icon "note" sanitized: removed attribute onload from <svg>: event handler` {
+			t.Errorf("Unexpected diagnostics: %v", diagnostics)
+		}
+		if set.Icons()["note"] != `<svg viewBox="0 0 24 24"><path d="M1 1"/></svg>` {
+			t.Errorf("Expected a sanitized icon, got %q", set.Icons()["note"])
+		}
+
+		set, diagnostics, _ = ParseIconSet(strings.NewReader("note|"+maliciousIcon+"\n"), WithIconSanitizer(nil))
+		if len(diagnostics) != 0 || set.Icons()["note"] != maliciousIcon {
+			t.Errorf("Expected the icon verbatim without a sanitizer, got %q %v", set.Icons()["note"], diagnostics)
+		}
+		if _, _, err := ParseIconSet(strings.NewReader("note|"+maliciousIcon+"\n"), WithStrictIcons(true)); err == nil {
+			t.Error("Expected strict mode to fail on sanitized icons")
+		}
+	})
+}
@@ -0,0 +1,28 @@
+package alertcallouts
+
+import (
+	"github.com/yuin/goldmark/renderer"
+
+	alertRenderer "github.com/zmtcreative/gm-alert-callouts/internal/renderer"
+)
+
+// SlackBlock is a Slack Block Kit section block, as written by the Slack renderer.
+type SlackBlock = alertRenderer.SlackBlock
+
+// SlackText is the text object of a SlackBlock.
+type SlackText = alertRenderer.SlackText
+
+// SlackRenderer returns a goldmark node renderer that writes each callout as a Slack Block Kit
+// section block with mrkdwn text: the kind's emoji (set with WithEmoji) and the bold title,
+// followed by the body as plain text. Nested callouts are written as block quotes.
+//
+// Each block is written as a single line of JSON, so the output can be split on newlines and
+// decoded into SlackBlock values for the `blocks` array of a message.
+func (e *alertCalloutsOptions) SlackRenderer() renderer.NodeRenderer {
+	return alertRenderer.NewAlertsSlackRenderer(alertRenderer.SlackConfig{
+		Aliases:     e.config.Aliases,
+		GFMKinds:    e.config.GFMKinds,
+		AllowNOICON: e.config.AllowNOICON,
+		Emoji:       e.config.Emoji,
+	})
+}
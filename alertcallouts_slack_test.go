@@ -0,0 +1,43 @@
+package alertcallouts
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSlackRenderer(t *testing.T) {
+	t.Run("One block per callout", func(t *testing.T) {
+		ext := NewAlertCallouts(UseHybridIcons())
+		result := renderWith(t, ext, ext.SlackRenderer(), "> [!tip]\n> First\n\nText\n\n> [!warn] Careful\n> Second")
+
+		lines := strings.Split(strings.TrimSuffix(result, "\n"), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("Expected 2 blocks, got %d: %q", len(lines), result)
+		}
+
+		expected := []string{"💡 *Tip*\nFirst", "⚠️ *Careful*\nSecond"}
+		for i, line := range lines {
+			var block SlackBlock
+			if err := json.Unmarshal([]byte(line), &block); err != nil {
+				t.Fatalf("Block %d is not valid JSON: %v", i, err)
+			}
+			if block.Text.Text != expected[i] {
+				t.Errorf("Expected %q, got %q", expected[i], block.Text.Text)
+			}
+		}
+	})
+
+	t.Run("Extension emoji map", func(t *testing.T) {
+		ext := NewAlertCallouts(UseObsidianIcons(), WithEmoji(map[string]string{"question": ":thinking_face:"}))
+		result := renderWith(t, ext, ext.SlackRenderer(), "> [!faq]\n> Body")
+
+		var block SlackBlock
+		if err := json.Unmarshal([]byte(result), &block); err != nil {
+			t.Fatalf("Output is not valid JSON: %v", err)
+		}
+		if block.Text.Text != ":thinking_face: *Faq*\nBody" {
+			t.Errorf("Unexpected text: %q", block.Text.Text)
+		}
+	})
+}
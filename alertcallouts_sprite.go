@@ -0,0 +1,21 @@
+package alertcallouts
+
+import (
+	alertRenderer "github.com/zmtcreative/gm-alert-callouts/internal/renderer"
+)
+
+// WithSpriteURL makes the callout headers reference the symbols of an external sprite file in
+// IconsSprite mode, e.g. `<use href="/assets/callout-icons.svg#callout-icon-note"/>`, instead of a
+// sprite appended to each document. Write the file with IconSprite.
+func WithSpriteURL(url string) Option {
+	return func(opts *alertCalloutsOptions) {
+		opts.config.SpriteURL = url
+	}
+}
+
+// IconSprite returns a standalone SVG sprite with a `<symbol id="callout-icon-<kind>">` for every
+// icon of this extension. Aliases share the symbol of their primary kind.
+// Serve it as a file for WithSpriteURL, or include it once in a page layout.
+func (e *alertCalloutsOptions) IconSprite() string {
+	return alertRenderer.IconSprite(e.renderIcons(), e.config.Aliases, nil, e.config.CustomAlertsEnabled)
+}
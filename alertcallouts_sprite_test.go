@@ -0,0 +1,82 @@
+package alertcallouts
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/yuin/goldmark"
+)
+
+func convertWith(t *testing.T, ext *alertCalloutsOptions, source string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := goldmark.New(goldmark.WithExtensions(ext)).Convert([]byte(source), &buf); err != nil {
+		t.Fatalf("Convert returned error: %v", err)
+	}
+	return buf.String()
+}
+
+func TestSpriteIconMode(t *testing.T) {
+	t.Run("Sprite appended once per document", func(t *testing.T) {
+		ext := NewAlertCallouts(UseHybridIcons(), WithIconMode(IconsSprite))
+		result := convertWith(t, ext, "> [!info]\n> a\n\n> [!note]\n> b\n\n> [!tip]\n> c\n")
+
+		if strings.Count(result, `<use href="#callout-icon-note"/>`) != 2 {
+			t.Errorf("Expected 'info' and 'note' to reference the note symbol, got:\n%s", result)
+		}
+		if strings.Count(result, `<svg xmlns="http://www.w3.org/2000/svg" style="display:none">`) != 1 {
+			t.Errorf("Expected exactly one sprite, got:\n%s", result)
+		}
+		if strings.Count(result, "<symbol") != 2 {
+			t.Errorf("Expected symbols for 'note' and 'tip' only, got:\n%s", result)
+		}
+		if !strings.HasSuffix(result, "</svg>\n") {
+			t.Errorf("Expected the sprite at the end of the document, got:\n%s", result)
+		}
+	})
+
+	t.Run("No sprite without callouts", func(t *testing.T) {
+		ext := NewAlertCallouts(UseHybridIcons(), WithIconMode(IconsSprite))
+		if result := convertWith(t, ext, "Plain text\n"); strings.Contains(result, "<svg") {
+			t.Errorf("Expected no sprite, got %q", result)
+		}
+	})
+
+	t.Run("External sprite file", func(t *testing.T) {
+		ext := NewAlertCallouts(UseGFMStrictIcons(), WithIconMode(IconsSprite), WithSpriteURL("/icons.svg"))
+		result := convertWith(t, ext, "> [!WARNING]\n> a\n")
+
+		if !strings.Contains(result, `<use href="/icons.svg#callout-icon-warning"/>`) {
+			t.Errorf("Expected a reference into the external sprite, got:\n%s", result)
+		}
+		if strings.Contains(result, "<symbol") {
+			t.Errorf("Expected no sprite in the document, got:\n%s", result)
+		}
+	})
+
+	t.Run("Inline mode is the default", func(t *testing.T) {
+		ext := NewAlertCallouts(UseGFMStrictIcons())
+		result := convertWith(t, ext, "> [!NOTE]\n> a\n")
+		if strings.Contains(result, "<use") || !strings.Contains(result, ext.GetConfig().Icons["note"]) {
+			t.Errorf("Expected the inline icon, got:\n%s", result)
+		}
+	})
+}
+
+func TestIconSprite(t *testing.T) {
+	ext := NewAlertCallouts(UseHybridIcons())
+	sprite := ext.IconSprite()
+
+	if strings.Contains(sprite, `id="callout-icon-info"`) || strings.Contains(sprite, `id="callout-icon-hint"`) {
+		t.Error("Expected aliases to share the symbols of their primary kinds")
+	}
+	for kind := range ext.GetConfig().Icons {
+		if _, isAlias := ext.GetConfig().Aliases[kind]; isAlias {
+			continue
+		}
+		if !strings.Contains(sprite, `<symbol id="callout-icon-`+kind+`"`) {
+			t.Errorf("Expected a symbol for %q", kind)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package alertcallouts
+
+import (
+	alertRenderer "github.com/zmtcreative/gm-alert-callouts/internal/renderer"
+)
+
+// Palette holds the accent color of a kind for light and dark color schemes as CSS color values.
+type Palette = alertRenderer.Palette
+
+type stylesheetOptions struct {
+	config alertRenderer.StylesheetConfig
+}
+
+// StylesheetOption is a functional option for configuring the generated stylesheet.
+type StylesheetOption func(*stylesheetOptions)
+
+// WithStylesheetPalette overrides the light and dark colors of a single kind.
+// Aliases of the kind pick up the new colors too, and a kind without an icon, such as a custom
+// kind, gets a color rule of its own.
+func WithStylesheetPalette(kind string, light string, dark string) StylesheetOption {
+	return func(opts *stylesheetOptions) {
+		opts.config.Palettes[kind] = Palette{Light: light, Dark: dark}
+		opts.config.Kinds = append(opts.config.Kinds, kind)
+	}
+}
+
+// WithStylesheetPalettes replaces the default palettes of the icon set with the given ones.
+// Kinds without an entry use the default palette, and kinds with an entry but no icon get a
+// color rule of their own.
+func WithStylesheetPalettes(palettes map[string]Palette) StylesheetOption {
+	return func(opts *stylesheetOptions) {
+		opts.config.Palettes = make(map[string]Palette, len(palettes))
+		for kind, palette := range palettes {
+			opts.config.Palettes[kind] = palette
+			opts.config.Kinds = append(opts.config.Kinds, kind)
+		}
+	}
+}
+
+// WithStylesheetDefaultPalette sets the colors of kinds without a palette, such as custom kinds.
+func WithStylesheetDefaultPalette(light string, dark string) StylesheetOption {
+	return func(opts *stylesheetOptions) {
+		opts.config.Default = Palette{Light: light, Dark: dark}
+	}
+}
+
+// WithStylesheetDarkSelector switches to the dark palette under the given selector, e.g.
+// `[data-theme="dark"]`, instead of the prefers-color-scheme media query.
+func WithStylesheetDarkSelector(selector string) StylesheetOption {
+	return func(opts *stylesheetOptions) {
+		opts.config.DarkSelector = selector
+	}
+}
+
+// Stylesheet returns a complete callout stylesheet for the configuration of this extension: a
+// color rule for every kind and alias of the icon set (only the primary kinds with
+// WithCanonicalKinds), a rule for the iconset-<name> class of the icon set in use, rules for
+// foldable `<details>` callouts when folding is enabled and the icon stylesheet in the IconsCSS
+// mode. Colors are CSS custom properties (`--callout-color-<kind>`) with light and dark values,
+// taken from the default palettes of the built-in icon set. Colors set with WithColors or the
+// color field of an icon set replace the palettes of their kinds, and the options replace both.
+// Kinds with a palette of their own get a color rule even without an icon.
+func (e *alertCalloutsOptions) Stylesheet(options ...StylesheetOption) string {
+	palettes := alertRenderer.DefaultPalettes(e.config.DefaultIcons)
+	var kinds []string
+	for kind, color := range e.config.Colors {
+		if e.colorKinds[kind] {
+			palettes[kind] = Palette{Light: "#" + color, Dark: "#" + color}
+			kinds = append(kinds, kind)
+		}
+	}
+
+	opts := &stylesheetOptions{
+		config: alertRenderer.StylesheetConfig{
+			Icons:               e.renderIcons(),
+			Kinds:               kinds,
+			Aliases:             e.config.Aliases,
+			GFMKinds:            e.config.GFMKinds,
+			DefaultIcons:        e.config.DefaultIcons,
+			IconSet:             e.config.IconSet,
+			FoldingEnabled:      e.config.FoldingEnabled,
+			CustomAlertsEnabled: e.config.CustomAlertsEnabled,
+			IconMode:            e.config.IconMode,
+			CanonicalKinds:      e.config.CanonicalKinds,
+			Palettes:            palettes,
+		},
+	}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	return alertRenderer.Stylesheet(opts.config)
+}
@@ -0,0 +1,126 @@
+package alertcallouts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStylesheet(t *testing.T) {
+	t.Run("Every kind and alias of the presets has a color", func(t *testing.T) {
+		for name, option := range map[string]Option{"gfm": UseGFMStrictIcons(), "hybrid": UseHybridIcons(), "obsidian": UseObsidianIcons()} {
+			ext := NewAlertCallouts(option)
+			css := ext.Stylesheet()
+			for kind := range ext.GetConfig().Icons {
+				if !strings.Contains(css, ".callout-"+kind+",\n") && !strings.Contains(css, ".callout-"+kind+" {\n") {
+					t.Errorf("%s: expected a color rule for %q", name, kind)
+				}
+			}
+			if !strings.Contains(css, ".callout.iconset-") {
+				t.Errorf("%s: expected an iconset class rule", name)
+			}
+		}
+	})
+
+	t.Run("Emoji and registered icon sets have an iconset rule", func(t *testing.T) {
+		if err := RegisterIconSet("stylebrand", LoadIconSet("brand|<svg>brand</svg>")); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		t.Cleanup(func() {
+			iconSetsMu.Lock()
+			delete(iconSets, "stylebrand")
+			iconSetsMu.Unlock()
+		})
+		for name, expected := range map[string]string{"emoji": ".callout.iconset-emoji ", "stylebrand": ".callout.iconset-stylebrand {"} {
+			if css := NewAlertCallouts(UseIconSet(name)).Stylesheet(); !strings.Contains(css, expected) {
+				t.Errorf("%s: expected %q in:\n%s", name, expected, css)
+			}
+		}
+	})
+
+	t.Run("Kind colors replace the palettes", func(t *testing.T) {
+		set := LoadIconSet("brand|<svg>brand</svg>\nnote|<svg>note</svg>\nbrand.color=#123456")
+		css := NewAlertCallouts(WithColors(map[string]string{"note": "ABCDEF"}), WithIconSet(set)).Stylesheet()
+		for _, expected := range []string{
+			"--callout-color-brand: #123456;",
+			"--callout-color-note: #ABCDEF;",
+			".callout-brand {\n  --callout-color: var(--callout-color-brand);\n}\n",
+		} {
+			if !strings.Contains(css, expected) {
+				t.Errorf("Expected %q in:\n%s", expected, css)
+			}
+		}
+		if strings.Count(css, "--callout-color-note: #ABCDEF;") != 2 {
+			t.Errorf("Expected the color in both color schemes, got:\n%s", css)
+		}
+
+		css = NewAlertCallouts(UseHybridIcons(), WithColors(map[string]string{"note": "0969DA"})).Stylesheet()
+		if strings.Count(css, "--callout-color-note: #0969DA;") != 2 {
+			t.Errorf("Expected a color equal to the built-in one to replace the palette, got:\n%s", css)
+		}
+		if !strings.Contains(NewAlertCallouts(WithIconSet(set)).Stylesheet(), "--callout-color-note: #086ddd;") {
+			t.Error("Expected the built-in colors merged into the icon set colors to keep the palettes")
+		}
+
+		css = NewAlertCallouts(WithIconSet(set)).Stylesheet(WithStylesheetPalette("brand", "red", "maroon"))
+		if !strings.Contains(css, "--callout-color-brand: red;") {
+			t.Errorf("Expected the stylesheet option to win, got:\n%s", css)
+		}
+	})
+
+	t.Run("Folding rules follow the configuration", func(t *testing.T) {
+		if css := NewAlertCallouts(UseGFMStrictIcons()).Stylesheet(); strings.Contains(css, "details.callout") {
+			t.Error("Expected no folding rules for GFM Strict")
+		}
+		if css := NewAlertCallouts(UseObsidianIcons()).Stylesheet(); !strings.Contains(css, "details.callout") {
+			t.Error("Expected folding rules for Obsidian")
+		}
+	})
+
+	t.Run("Per-kind overrides", func(t *testing.T) {
+		ext := NewAlertCallouts(UseHybridIcons())
+		css := ext.Stylesheet(
+			WithStylesheetPalette("note", "#000001", "#000002"),
+			WithStylesheetDefaultPalette("#000003", "#000004"),
+			WithStylesheetDarkSelector(".dark"),
+		)
+		for _, expected := range []string{
+			"--callout-color-note: #000001;",
+			".dark {\n  --callout-color-default: #000004;\n  --callout-color-bug:",
+			"--callout-color-note: #000002;",
+			"--callout-color-default: #000003;",
+		} {
+			if !strings.Contains(css, expected) {
+				t.Errorf("Expected %q in:\n%s", expected, css)
+			}
+		}
+		if !strings.Contains(ext.Stylesheet(), "--callout-color-note: #086ddd;") {
+			t.Error("Expected overrides not to leak into the default palettes")
+		}
+	})
+
+	t.Run("Palettes of kinds without an icon", func(t *testing.T) {
+		ext := NewAlertCallouts(UseHybridIcons())
+		css := ext.Stylesheet(WithStylesheetPalette("security", "#aa0000", "#ff5555"))
+		if !strings.Contains(css, "--callout-color-security: #aa0000;") || !strings.Contains(css, ".callout-security {\n") {
+			t.Errorf("Expected a color rule for security, got:\n%s", css)
+		}
+		css = NewAlertCallouts(WithColors(map[string]string{"security": "AA0000"}), UseHybridIcons()).Stylesheet()
+		if !strings.Contains(css, "--callout-color-security: #AA0000;") || !strings.Contains(css, ".callout-security {\n") {
+			t.Errorf("Expected a color rule for a kind colored with WithColors, got:\n%s", css)
+		}
+	})
+
+	t.Run("Replaced palettes", func(t *testing.T) {
+		css := NewAlertCallouts(UseGFMStrictIcons()).Stylesheet(WithStylesheetPalettes(map[string]Palette{"note": {Light: "navy", Dark: "skyblue"}}))
+		if !strings.Contains(css, "--callout-color-note: navy;") || strings.Contains(css, "--callout-color-tip") {
+			t.Errorf("Expected only the given palettes, got:\n%s", css)
+		}
+	})
+
+	t.Run("CSS icon mode includes the icon stylesheet", func(t *testing.T) {
+		css := NewAlertCallouts(UseGFMStrictIcons(), WithIconMode(IconsCSS)).Stylesheet()
+		if !strings.Contains(css, "mask-image") {
+			t.Error("Expected the icon stylesheet in IconsCSS mode")
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package alertcallouts
+
+import (
+	"os"
+
+	"github.com/yuin/goldmark/renderer"
+
+	alertRenderer "github.com/zmtcreative/gm-alert-callouts/internal/renderer"
+)
+
+type terminalOptions struct {
+	config alertRenderer.TerminalConfig
+	output *os.File
+	color  *bool
+}
+
+// TerminalOption is a functional option for configuring the ANSI terminal renderer.
+type TerminalOption func(*terminalOptions)
+
+// WithTerminalColor forces ANSI colors on or off instead of detecting terminal support.
+func WithTerminalColor(enable bool) TerminalOption {
+	return func(opts *terminalOptions) {
+		opts.color = &enable
+	}
+}
+
+// WithTerminalOutput sets the file used to detect color support (os.Stdout by default).
+// Colors are only written when the file is a terminal and NO_COLOR is not set.
+func WithTerminalOutput(f *os.File) TerminalOption {
+	return func(opts *terminalOptions) {
+		opts.output = f
+	}
+}
+
+// WithTerminalWidth sets the total width of callout boxes in columns.
+func WithTerminalWidth(width int) TerminalOption {
+	return func(opts *terminalOptions) {
+		opts.config.Width = width
+	}
+}
+
+// WithTerminalColors sets the ANSI SGR color parameter (e.g. "34" or "38;5;33") for each alert kind.
+// Aliases are drawn in the color of their primary kind unless they have an entry of their own.
+func WithTerminalColors(colors map[string]string) TerminalOption {
+	return func(opts *terminalOptions) {
+		opts.config.Colors = colors
+	}
+}
+
+// WithTerminalGlyphs sets the Unicode glyph drawn in place of the SVG icon for each alert kind.
+// An alias without a glyph shows the glyph of its primary kind.
+func WithTerminalGlyphs(glyphs map[string]string) TerminalOption {
+	return func(opts *terminalOptions) {
+		opts.config.Glyphs = glyphs
+	}
+}
+
+// WithTerminalASCII sets whether boxes are drawn with ASCII characters instead of Unicode box-drawing characters.
+func WithTerminalASCII(enable bool) TerminalOption {
+	return func(opts *terminalOptions) {
+		if enable {
+			opts.config.Border = alertRenderer.TerminalBorderASCII
+		} else {
+			opts.config.Border = alertRenderer.TerminalBorderRounded
+		}
+	}
+}
+
+// TerminalRenderer returns a goldmark node renderer that draws callouts as colored, bordered boxes
+// for display in a terminal, using the icon set and folding settings of this extension.
+// Folded callouts (`[!kind]-`) are drawn collapsed with a hint showing how many lines are hidden.
+//
+// Colors are written only when the output is a terminal and the NO_COLOR environment variable
+// is not set, unless WithTerminalColor is used.
+func (e *alertCalloutsOptions) TerminalRenderer(options ...TerminalOption) renderer.NodeRenderer {
+	opts := &terminalOptions{
+		config: alertRenderer.TerminalConfig{
+			Aliases:        e.config.Aliases,
+			GFMKinds:       e.config.GFMKinds,
+			FoldingEnabled: e.config.FoldingEnabled,
+			AllowNOICON:    e.config.AllowNOICON,
+			Border:         alertRenderer.TerminalBorderRounded,
+			Width:          80,
+		},
+		output: os.Stdout,
+	}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	if opts.color != nil {
+		opts.config.Color = *opts.color
+	} else {
+		opts.config.Color = alertRenderer.ColorSupported(opts.output)
+	}
+
+	return alertRenderer.NewAlertsTerminalRenderer(opts.config)
+}
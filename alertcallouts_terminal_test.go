@@ -0,0 +1,59 @@
+package alertcallouts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTerminalRenderer(t *testing.T) {
+	t.Run("Plain box when color is disabled", func(t *testing.T) {
+		ext := NewAlertCallouts(UseHybridIcons())
+		nr := ext.TerminalRenderer(WithTerminalColor(false), WithTerminalWidth(20), WithTerminalASCII(true))
+		result := renderWith(t, ext, nr, "> [!hint]\n> Body text")
+
+		expected := "" +
+			"+- ★ Hint ---------+\n" +
+			"| Body text        |\n" +
+			"+------------------+\n"
+		if result != expected {
+			t.Errorf("Expected:\n%s\nGot:\n%s", expected, result)
+		}
+	})
+
+	t.Run("NO_COLOR disables detected color", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+		ext := NewAlertCallouts(UseHybridIcons())
+		result := renderWith(t, ext, ext.TerminalRenderer(), "> [!note]\n> Body text")
+
+		if strings.Contains(result, "\x1b[") {
+			t.Errorf("Expected no ANSI escapes, got %q", result)
+		}
+	})
+
+	t.Run("Forced color with custom colors and glyphs", func(t *testing.T) {
+		ext := NewAlertCallouts(UseGFMStrictIcons())
+		nr := ext.TerminalRenderer(
+			WithTerminalColor(true),
+			WithTerminalColors(map[string]string{"tip": "38;5;42"}),
+			WithTerminalGlyphs(map[string]string{"tip": "+"}),
+		)
+		result := renderWith(t, ext, nr, "> [!TIP]\n> Body text")
+
+		if !strings.Contains(result, "\x1b[1;38;5;42m+ Tip\x1b[0m") {
+			t.Errorf("Expected custom color and glyph, got %q", result)
+		}
+	})
+
+	t.Run("Folded callout is collapsed", func(t *testing.T) {
+		ext := NewAlertCallouts(UseObsidianIcons())
+		nr := ext.TerminalRenderer(WithTerminalColor(false))
+		result := renderWith(t, ext, nr, "> [!faq]- Why?\n> Because")
+
+		if !strings.Contains(result, "▸ ? Why?") || !strings.Contains(result, "1 line folded") {
+			t.Errorf("Expected collapsed callout with hint, got:\n%s", result)
+		}
+		if strings.Contains(result, "Because") {
+			t.Errorf("Expected folded body to be hidden, got:\n%s", result)
+		}
+	})
+}
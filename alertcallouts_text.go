@@ -0,0 +1,91 @@
+package alertcallouts
+
+import (
+	"github.com/yuin/goldmark/renderer"
+
+	alertRenderer "github.com/zmtcreative/gm-alert-callouts/internal/renderer"
+)
+
+// TextLabelFormat selects how the label line of a callout is written in plain-text output.
+type TextLabelFormat = alertRenderer.TextLabelFormat
+
+const (
+	// TextLabelKind writes the upper-cased kind followed by the custom title, e.g. "NOTE: Read this".
+	TextLabelKind = alertRenderer.TextLabelKind
+	// TextLabelTitle writes the custom title, or the title-cased kind when there is none, e.g. "Note".
+	TextLabelTitle = alertRenderer.TextLabelTitle
+)
+
+type textOptions struct {
+	config alertRenderer.TextConfig
+}
+
+// TextOption is a functional option for configuring the plain-text renderer.
+type TextOption func(*textOptions)
+
+// WithTextLabel sets the label format used for the first line of each callout.
+func WithTextLabel(format TextLabelFormat) TextOption {
+	return func(opts *textOptions) {
+		opts.config.Label = format
+	}
+}
+
+// WithTextEmoji sets whether the label is prefixed with an emoji for the alert kind.
+func WithTextEmoji(enable bool) TextOption {
+	return func(opts *textOptions) {
+		opts.config.Emoji = enable
+	}
+}
+
+// WithTextEmojiMap sets the emoji used for each alert kind when emoji prefixes are enabled,
+// overriding the extension's WithEmoji map for this renderer.
+// An alias missing from the map is prefixed with the emoji of its primary kind.
+func WithTextEmojiMap(emoji map[string]string) TextOption {
+	return func(opts *textOptions) {
+		opts.config.EmojiMap = emoji
+	}
+}
+
+// WithTextIndent sets the number of spaces the callout body is indented by.
+func WithTextIndent(spaces int) TextOption {
+	return func(opts *textOptions) {
+		opts.config.Indent = spaces
+	}
+}
+
+// WithTextWidth sets the column that callout text is wrapped at. A width of 0 disables wrapping.
+func WithTextWidth(width int) TextOption {
+	return func(opts *textOptions) {
+		opts.config.Width = width
+	}
+}
+
+// TextRenderer returns a goldmark node renderer that writes callouts as plain text, using the
+// icon set and folding settings of this extension. A callout is written as a label line such as
+// `NOTE: title` followed by the indented body text.
+//
+// Add it to a plain-text goldmark renderer alongside the renderers for the core nodes:
+//
+//	renderer.NewRenderer(renderer.WithNodeRenderers(
+//		util.Prioritized(myTextRenderer, 1000),
+//		util.Prioritized(ext.TextRenderer(alertcallouts.WithTextWidth(72)), 100),
+//	))
+func (e *alertCalloutsOptions) TextRenderer(options ...TextOption) renderer.NodeRenderer {
+	opts := &textOptions{
+		config: alertRenderer.TextConfig{
+			Aliases:        e.config.Aliases,
+			GFMKinds:       e.config.GFMKinds,
+			FoldingEnabled: e.config.FoldingEnabled,
+			AllowNOICON:    e.config.AllowNOICON,
+			EmojiMap:       e.config.Emoji,
+			Label:          TextLabelKind,
+			Indent:         4,
+		},
+	}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	return alertRenderer.NewAlertsTextRenderer(opts.config)
+}
@@ -0,0 +1,83 @@
+package alertcallouts
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// renderWith parses input with a goldmark instance using ext and renders the document with nr as the
+// only node renderer, so the result contains only the output for the callouts.
+func renderWith(t *testing.T, ext *alertCalloutsOptions, nr renderer.NodeRenderer, input string) string {
+	t.Helper()
+	md := goldmark.New(goldmark.WithExtensions(ext))
+	source := []byte(input)
+	doc := md.Parser().Parse(text.NewReader(source))
+
+	var buf bytes.Buffer
+	r := renderer.NewRenderer(renderer.WithNodeRenderers(util.Prioritized(nr, 0)))
+	if err := r.Render(&buf, source, doc); err != nil {
+		t.Fatalf("Failed to render markdown: %v", err)
+	}
+	return buf.String()
+}
+
+func TestTextRenderer(t *testing.T) {
+	t.Run("Default options", func(t *testing.T) {
+		ext := NewAlertCallouts(UseHybridIcons())
+		result := renderWith(t, ext, ext.TextRenderer(), "> [!note] Heads up\n> Body text")
+
+		expected := "NOTE: Heads up\n    Body text\n"
+		if result != expected {
+			t.Errorf("Expected %q, got %q", expected, result)
+		}
+	})
+
+	t.Run("Emoji prefix with hybrid alias", func(t *testing.T) {
+		ext := NewAlertCallouts(UseHybridIcons())
+		result := renderWith(t, ext, ext.TextRenderer(WithTextEmoji(true), WithTextIndent(2)), "> [!hint]\n> Body text")
+
+		expected := "💡 HINT\n  Body text\n"
+		if result != expected {
+			t.Errorf("Expected %q, got %q", expected, result)
+		}
+	})
+
+	t.Run("Primary kinds sharing an icon stay apart", func(t *testing.T) {
+		// In the emoji icons note and info are both primary kinds with the same icon.
+		ext := NewAlertCallouts(UseEmojiIcons())
+		r := ext.TextRenderer(WithTextEmoji(true), WithTextEmojiMap(map[string]string{"info": "I"}))
+		result := renderWith(t, ext, r, "> [!note]\n> Body text")
+
+		expected := "NOTE\n    Body text\n"
+		if result != expected {
+			t.Errorf("Expected %q, got %q", expected, result)
+		}
+	})
+
+	t.Run("Title label and wrapping", func(t *testing.T) {
+		ext := NewAlertCallouts(UseGFMStrictIcons())
+		nr := ext.TextRenderer(WithTextLabel(TextLabelTitle), WithTextIndent(2), WithTextWidth(13))
+		result := renderWith(t, ext, nr, "> [!WARNING]\n> alpha beta gamma delta")
+
+		expected := "Warning\n  alpha beta\n  gamma delta\n"
+		if result != expected {
+			t.Errorf("Expected %q, got %q", expected, result)
+		}
+	})
+
+	t.Run("Custom emoji map", func(t *testing.T) {
+		ext := NewAlertCallouts(UseGFMStrictIcons())
+		nr := ext.TextRenderer(WithTextEmoji(true), WithTextEmojiMap(map[string]string{"tip": "*"}))
+		result := renderWith(t, ext, nr, "> [!TIP]\n> Body text")
+
+		expected := "* TIP\n    Body text\n"
+		if result != expected {
+			t.Errorf("Expected %q, got %q", expected, result)
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package alertcallouts
+
+import (
+	"io"
+
+	alertRenderer "github.com/zmtcreative/gm-alert-callouts/internal/renderer"
+	utils "github.com/zmtcreative/gm-alert-callouts/internal/utilities"
+)
+
+// IconSetSchemaVersion is the version of the JSON form of icon sets (see docs/ICONMAPS.md).
+const IconSetSchemaVersion = utils.IconSetSchemaVersion
+
+// IconSetDocument is the JSON form of an icon set, written by IconSet.WriteJSON and read by
+// ParseIconSetJSON.
+type IconSetDocument = utils.IconSetDocument
+
+// IconSetEntry is the JSON form of a primary kind or alias, with the fields it sets itself and
+// the comment blocks before it.
+type IconSetEntry = utils.IconSetEntry
+
+// ParseIconSetJSON reads the JSON form of an icon set written by IconSet.WriteJSON, so icon sets
+// can round-trip between tools. Kinds are checked like the lines of an .icons file and sanitized
+// like ParseIconSet does; diagnostics give the 1-based index of the kind in the kinds array as
+// the line. An error is returned for invalid JSON, an unknown schema version, or in strict mode
+// (WithStrictIcons) when there are warnings.
+func ParseIconSetJSON(r io.Reader, options ...IconLoadOption) (*IconSet, []Diagnostic, error) {
+	opts := newIconLoadOptions(options)
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	set, diagnostics, err := utils.ParseIconSetJSON(data, opts.file)
+	if err != nil {
+		return nil, nil, err
+	}
+	return opts.finish(set, diagnostics)
+}
+
+// WriteIconSprite writes a standalone SVG sprite with a `<symbol id="callout-icon-<kind>">` for
+// every SVG icon of the icon set, ready to serve for WithSpriteURL. Aliases share the symbol of
+// their primary kind; icons that are not SVG are left out. The icons are written as they are, so
+// icon sets that do not come from the loader should be sanitized first (see IconSet.Sanitized).
+func WriteIconSprite(w io.Writer, set *IconSet) error {
+	sprite := alertRenderer.IconSprite(set.Icons(), set.Aliases(), nil, false)
+	if sprite == "" {
+		sprite = `<svg xmlns="http://www.w3.org/2000/svg" style="display:none"></svg>` + "\n"
+	}
+	_, err := io.WriteString(w, sprite)
+	return err
+}
@@ -0,0 +1,102 @@
+package alertcallouts
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIconSetRoundTrip(t *testing.T) {
+	for _, name := range Presets() {
+		set, ok := LookupIconSet(name)
+		if !ok {
+			t.Fatalf("Expected the %q preset to be found", name)
+		}
+
+		check := func(t *testing.T, again *IconSet, diagnostics []Diagnostic, err error) {
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(diagnostics) > 0 {
+				t.Errorf("Expected no diagnostics, got %v", diagnostics)
+			}
+			if !reflect.DeepEqual(again.Icons(), set.Icons()) {
+				t.Error("Expected the same icons")
+			}
+			if !reflect.DeepEqual(again.Aliases(), set.Aliases()) {
+				t.Errorf("Expected the same aliases, got %v", again.Aliases())
+			}
+			if !reflect.DeepEqual(again.Titles(), set.Titles()) || !reflect.DeepEqual(again.GFMKinds(), set.GFMKinds()) {
+				t.Errorf("Expected the same fields, got %v and %v", again.Titles(), again.GFMKinds())
+			}
+			if !reflect.DeepEqual(again.Groups(), set.Groups()) {
+				t.Errorf("Expected the same groups, got %v", again.Groups())
+			}
+		}
+
+		t.Run(name+" icons", func(t *testing.T) {
+			var sb strings.Builder
+			if err := set.WriteIcons(&sb); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			again, diagnostics, err := ParseIconSet(strings.NewReader(sb.String()))
+			check(t, again, diagnostics, err)
+		})
+		t.Run(name+" JSON", func(t *testing.T) {
+			var sb strings.Builder
+			if err := set.WriteJSON(&sb); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			again, diagnostics, err := ParseIconSetJSON(strings.NewReader(sb.String()))
+			check(t, again, diagnostics, err)
+		})
+	}
+}
+
+func TestParseIconSetJSON(t *testing.T) {
+	data := `{"version": 1, "kinds": [{"kind": "note", "icon": "` + strings.ReplaceAll(maliciousIcon, `"`, `\"`) + `"}]}`
+
+	set, diagnostics, err := ParseIconSetJSON(strings.NewReader(data), WithIconFileName("icons.json"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if icon := set.Icons()["note"]; strings.Contains(icon, "alert") {
+		t.Errorf("Expected the icon to be sanitized, got %q", icon)
+	}
+	if len(diagnostics) == 0 || !strings.HasPrefix(diagnostics[0].String(), "icons.json:1:") {
+		t.Errorf("Expected the removals to be reported, got %v", diagnostics)
+	}
+
+	var setErr *IconSetError
+	if _, _, err := ParseIconSetJSON(strings.NewReader(data), WithStrictIcons(true)); !errors.As(err, &setErr) {
+		t.Errorf("Expected an *IconSetError in strict mode, got %v", err)
+	}
+	if _, _, err := ParseIconSetJSON(strings.NewReader(`{"version": 99}`)); err == nil {
+		t.Error("Expected an error for an unknown schema version")
+	}
+}
+
+func TestWriteIconSprite(t *testing.T) {
+	set := LoadIconSet("note|<svg viewBox=\"0 0 16 16\"><path d=\"M1 1\"/></svg>\ninfo->note\nsmile|emoji:😀\n")
+
+	var sb strings.Builder
+	if err := WriteIconSprite(&sb, set); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	sprite := sb.String()
+	if !strings.HasPrefix(sprite, `<svg xmlns="http://www.w3.org/2000/svg"`) || !strings.HasSuffix(sprite, "</svg>\n") {
+		t.Errorf("Expected a standalone sprite, got %q", sprite)
+	}
+	if strings.Count(sprite, "<symbol") != 1 || !strings.Contains(sprite, `id="callout-icon-note"`) {
+		t.Errorf("Expected one symbol shared by the alias, got %q", sprite)
+	}
+
+	sb.Reset()
+	if err := WriteIconSprite(&sb, LoadIconSet("smile|emoji:😀\n")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(sb.String(), "<svg") || strings.Contains(sb.String(), "<symbol") {
+		t.Errorf("Expected an empty sprite, got %q", sb.String())
+	}
+}
@@ -1,6 +1,8 @@
 package ast
 
 import (
+	"strings"
+
 	"github.com/zmtcreative/gm-alert-callouts/internal/constants"
 	gast "github.com/yuin/goldmark/ast"
 )
@@ -55,3 +57,22 @@ func (n *AlertsBody) Kind() gast.NodeKind {
 func NewAlertsBody() *AlertsBody {
 	return &AlertsBody{}
 }
+
+// AlertsSprite represents the SVG sprite holding the icons of the alerts in a document.
+// It is appended to the document by the sprite transformer when icons are rendered as sprite references.
+type AlertsSprite struct {
+	gast.BaseBlock
+	Kinds []string // Alert kinds used in the document, in order of first appearance
+}
+
+func (n *AlertsSprite) Dump(source []byte, level int) {
+	gast.DumpHelper(n, source, level, map[string]string{"Kinds": strings.Join(n.Kinds, ",")}, nil)
+}
+
+func (n *AlertsSprite) Kind() gast.NodeKind {
+	return constants.KindAlertsSprite
+}
+
+func NewAlertsSprite(kinds []string) *AlertsSprite {
+	return &AlertsSprite{Kinds: kinds}
+}
@@ -175,3 +175,31 @@ func TestNodeKindUniqueness(t *testing.T) {
 		}
 	})
 }
+
+func TestAlertsSpriteNode(t *testing.T) {
+	t.Run("NewAlertsSprite keeps the kinds", func(t *testing.T) {
+		node := NewAlertsSprite([]string{"note", "tip"})
+		if len(node.Kinds) != 2 || node.Kinds[0] != "note" || node.Kinds[1] != "tip" {
+			t.Errorf("Expected kinds [note tip], got %v", node.Kinds)
+		}
+	})
+
+	t.Run("AlertsSprite implements correct kind", func(t *testing.T) {
+		node := NewAlertsSprite(nil)
+		if node.Kind() != constants.KindAlertsSprite {
+			t.Errorf("Expected KindAlertsSprite, got %v", node.Kind())
+		}
+		if node.Type() != gast.TypeBlock {
+			t.Error("Expected AlertsSprite to have block type")
+		}
+	})
+
+	t.Run("AlertsSprite dump does not panic", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Errorf("Dump() panicked: %v", r)
+			}
+		}()
+		NewAlertsSprite([]string{"note"}).Dump([]byte("test"), 0)
+	})
+}
@@ -9,6 +9,22 @@ const (
 	ICONS_GFM
 	ICONS_HYBRID
 	ICONS_OBSIDIAN
+	ICONS_EMOJI
+)
+
+// Icon modes select how the header renderer includes the icon of a callout.
+const (
+	ICON_MODE_INLINE = iota // The full icon markup is written into every header
+	ICON_MODE_SPRITE        // Headers reference a symbol in an SVG sprite with <use>
+	ICON_MODE_CSS           // Headers contain an empty span that a stylesheet fills with a mask image
+)
+
+// Icon source prefixes declare icons that are not inline markup. An icon without a prefix is
+// written as it is.
+const (
+	ICON_PREFIX_IMAGE = "image:" // The rest of the icon is an image URL or data URI, written as <img>
+	ICON_PREFIX_FONT  = "font:"  // The rest of the icon is the class list of an icon font, written as <i>
+	ICON_PREFIX_EMOJI = "emoji:" // The rest of the icon is an emoji, written as <span role="img">
 )
 
 var FALLBACK_ICON_LIST = []string{"default", "icon", "custom", "note", "info"}
@@ -23,5 +39,119 @@ var (
 
 	// KindAlertsBody is the NodeKind for the alert body.
 	KindAlertsBody = gast.NewNodeKind("AlertsBody")
+
+	// KindAlertsSprite is the NodeKind for the SVG icon sprite appended to a document.
+	KindAlertsSprite = gast.NewNodeKind("AlertsSprite")
 )
 
+// KIND_EMOJI maps the primary alert kinds of the built-in icon sets to a Unicode emoji.
+// Output formats that cannot embed SVG use these in place of the icon.
+var KIND_EMOJI = map[string]string{
+	"note":      "ℹ️",
+	"tip":       "💡",
+	"important": "❗",
+	"warning":   "⚠️",
+	"caution":   "🛑",
+	"abstract":  "📋",
+	"summary":   "📋",
+	"info":      "ℹ️",
+	"todo":      "☑️",
+	"success":   "✅",
+	"question":  "❓",
+	"failure":   "❌",
+	"danger":    "⚡",
+	"bug":       "🐞",
+	"example":   "📝",
+	"quote":     "💬",
+	"scroll":    "📜",
+}
+
+// KIND_GLYPH maps the primary alert kinds of the built-in icon sets to a single-column Unicode glyph.
+// Terminal output uses these in place of the SVG icon.
+var KIND_GLYPH = map[string]string{
+	"note":      "ℹ",
+	"tip":       "★",
+	"important": "‼",
+	"warning":   "⚠",
+	"caution":   "✖",
+	"abstract":  "≡",
+	"summary":   "≡",
+	"info":      "ℹ",
+	"todo":      "☐",
+	"success":   "✔",
+	"question":  "?",
+	"failure":   "✘",
+	"danger":    "☠",
+	"bug":       "✱",
+	"example":   "☰",
+	"quote":     "❝",
+	"scroll":    "§",
+}
+
+// KIND_ANSI_COLOR maps the primary alert kinds of the built-in icon sets to an ANSI SGR color parameter.
+var KIND_ANSI_COLOR = map[string]string{
+	"note":      "34",
+	"tip":       "32",
+	"important": "35",
+	"warning":   "33",
+	"caution":   "31",
+	"abstract":  "36",
+	"summary":   "36",
+	"info":      "34",
+	"todo":      "34",
+	"success":   "32",
+	"question":  "33",
+	"failure":   "31",
+	"danger":    "31",
+	"bug":       "31",
+	"example":   "35",
+	"quote":     "90",
+	"scroll":    "36",
+}
+
+// KIND_COLOR maps the primary alert kinds of the built-in icon sets to an accent color as a
+// six-digit hex value without the leading '#'.
+var KIND_COLOR = map[string]string{
+	"note":      "0969DA",
+	"tip":       "1A7F37",
+	"important": "8250DF",
+	"warning":   "9A6700",
+	"caution":   "CF222E",
+	"abstract":  "0891B2",
+	"summary":   "0891B2",
+	"info":      "0969DA",
+	"todo":      "0969DA",
+	"success":   "1A7F37",
+	"question":  "BF8700",
+	"failure":   "CF222E",
+	"danger":    "CF222E",
+	"bug":       "CF222E",
+	"example":   "8250DF",
+	"quote":     "6E7781",
+	"scroll":    "0891B2",
+}
+
+// DEFAULT_COLOR is the accent color used for kinds that have no entry in KIND_COLOR.
+const DEFAULT_COLOR = "6E7781"
+
+// KIND_LATEX_SYMBOL maps the primary alert kinds of the built-in icon sets to a fontawesome5 command.
+// LaTeX output uses these in place of the SVG icon.
+var KIND_LATEX_SYMBOL = map[string]string{
+	"note":      `\faInfoCircle`,
+	"tip":       `\faLightbulb`,
+	"important": `\faExclamationCircle`,
+	"warning":   `\faExclamationTriangle`,
+	"caution":   `\faStopCircle`,
+	"abstract":  `\faClipboardList`,
+	"summary":   `\faClipboardList`,
+	"info":      `\faInfoCircle`,
+	"todo":      `\faCheckSquare`,
+	"success":   `\faCheck`,
+	"question":  `\faQuestionCircle`,
+	"failure":   `\faTimes`,
+	"danger":    `\faBolt`,
+	"bug":       `\faBug`,
+	"example":   `\faList`,
+	"quote":     `\faQuoteLeft`,
+	"scroll":    `\faScroll`,
+}
@@ -18,16 +18,31 @@ type alertParser struct{
 	IconList []string
 	FoldingEnabled bool
 	CustomAlertsEnabled bool
+	Policy KindPolicy
+}
+
+// KindPolicy restricts which alert kinds the parser accepts, independently of the icon list, and
+// sets their default fold state. Kinds are matched lower-cased and after the 'noicon-' prefix is stripped.
+type KindPolicy struct {
+	Allow []string          // When not empty, the only kinds accepted; they replace the icon list as the known kinds
+	Deny  []string          // Kinds that are never accepted, even with custom alerts enabled
+	Folds map[string]string // Fold state ("open" or "closed") of kinds written without '+' or '-', when folding is enabled
 }
 
 var defaultAlertsParser = &alertParser{}
 var _ = defaultAlertsParser
 
 func NewAlertsParser(iconList []string, foldingEnabled bool, customAlertsEnabled bool) parser.BlockParser {
+	return NewAlertsParserWithPolicy(iconList, foldingEnabled, customAlertsEnabled, KindPolicy{})
+}
+
+// NewAlertsParserWithPolicy returns an alerts parser that also applies the allow and deny lists of policy.
+func NewAlertsParserWithPolicy(iconList []string, foldingEnabled bool, customAlertsEnabled bool, policy KindPolicy) parser.BlockParser {
 	return &alertParser{
 		IconList:            iconList,
 		FoldingEnabled:      foldingEnabled,
 		CustomAlertsEnabled: customAlertsEnabled,
+		Policy:              policy,
 	}
 }
 
@@ -71,7 +86,7 @@ func (b *alertParser) Open(parent gast.Node, reader text.Reader, pc parser.Conte
 		return nil, parser.NoChildren
 	}
 
-	line, _ := reader.PeekLine()
+	line, lineSegment := reader.PeekLine()
 
 	// empty blockquote
 	if len(line) <= advanceBy {
@@ -113,13 +128,25 @@ func (b *alertParser) Open(parent gast.Node, reader text.Reader, pc parser.Conte
 		noicon = 1
 	}
 
+	// Denied kinds are never alerts, and an allow list limits the kinds to the ones it names.
+	//   The allow list also takes the place of the IconList, so allowed kinds without an icon still work.
+	knownKinds := b.IconList
+	if slices.Contains(b.Policy.Deny, lckind) {
+		return nil, parser.NoChildren
+	} else if len(b.Policy.Allow) > 0 {
+		if !slices.Contains(b.Policy.Allow, lckind) {
+			return nil, parser.NoChildren
+		}
+		knownKinds = b.Policy.Allow
+	}
+
 	// If CustomAlerts is not in use, disallow anything like:
 	//   - kind doesn't have an icon
 	//   - custom title not allowed
 	//   - folding symbols (+ and -) not allowed
 	if !b.CustomAlertsEnabled {
-		if !(slices.Contains(b.IconList, lckind)) {
-			// We'll reject any kind that isn't in the current IconList
+		if !(slices.Contains(knownKinds, lckind)) {
+			// We'll reject any kind that isn't in the current IconList (or allow list)
 			return nil, parser.NoChildren
 		} else if len(title) > 0 {
 			// GFM does not support custom titles, so including a custom title is disallowed
@@ -141,6 +168,17 @@ func (b *alertParser) Open(parent gast.Node, reader text.Reader, pc parser.Conte
 		return nil, parser.NoChildren
 	}
 
+	// Without '+' or '-' the default fold state of the kind, if any, decides whether it is foldable.
+	if b.FoldingEnabled && shouldFold == 0 {
+		switch b.Policy.Folds[lckind] {
+		case utilities.FoldOpen:
+			shouldFold = 1
+		case utilities.FoldClosed:
+			shouldFold = 1
+			closed = []uint8("-")
+		}
+	}
+
 	alert := ast.NewAlerts()
 
 	alert.SetAttributeString("kind", kind)
@@ -149,6 +187,12 @@ func (b *alertParser) Open(parent gast.Node, reader text.Reader, pc parser.Conte
 	alert.SetAttributeString("shouldfold", shouldFold != 0)
 	alert.SetAttributeString("noicon", noicon != 0)
 
+	// Record where the alert starts and (so far) stops in the source, so exporters can report a source range.
+	// The stop offset is moved forward by Continue as further lines are added to the alert.
+	_, pos := reader.Position()
+	alert.SetAttributeString("start", pos.Start)
+	alert.SetAttributeString("stop", lineSegment.Stop)
+
 	i := strings.Index(string(line), "]")
 	if i >= 0 {
 		reader.Advance(i)
@@ -163,6 +207,9 @@ func (b *alertParser) Continue(node gast.Node, reader text.Reader, pc parser.Con
 		return parser.Close
 	}
 
+	_, lineSegment := reader.PeekLine()
+	node.SetAttributeString("stop", lineSegment.Stop)
+
 	reader.Advance(advanceBy)
 
 	return parser.Continue | parser.HasChildren
@@ -33,7 +33,7 @@ func TestNewAlertsParser(t *testing.T) {
 }
 
 func TestAlertsParserTrigger(t *testing.T) {
-	p := &alertParser{[]string{"note"}, false, false}
+	p := &alertParser{[]string{"note"}, false, false, KindPolicy{}}
 	trigger := p.Trigger()
 	expected := []byte{'>'}
 
@@ -43,7 +43,7 @@ func TestAlertsParserTrigger(t *testing.T) {
 }
 
 func TestAlertsParserProcess(t *testing.T) {
-	p := &alertParser{[]string{"note"}, false, false}
+	p := &alertParser{[]string{"note"}, false, false, KindPolicy{}}
 
 	testCases := []struct {
 		name     string
@@ -106,7 +106,7 @@ func TestAlertsParserProcess(t *testing.T) {
 }
 
 func TestAlertsParserOpenNoCustomAlertsNoFolding(t *testing.T) {
-	p := &alertParser{[]string{"note", "warning", "info", "tip"}, true, true}
+	p := &alertParser{[]string{"note", "warning", "info", "tip"}, true, true, KindPolicy{}}
 	pc := parser.NewContext()
 
 	testCases := []struct {
@@ -246,7 +246,7 @@ func TestAlertsParserOpenNoCustomAlertsNoFolding(t *testing.T) {
 }
 
 func TestAlertsParserContinue(t *testing.T) {
-	p := &alertParser{[]string{"note"}, false, false}
+	p := &alertParser{[]string{"note"}, false, false, KindPolicy{}}
 	pc := parser.NewContext()
 	node := ast.NewAlerts()
 
@@ -285,7 +285,7 @@ func TestAlertsParserContinue(t *testing.T) {
 }
 
 func TestAlertsParserClose(t *testing.T) {
-	p := &alertParser{[]string{"note"}, false, false}
+	p := &alertParser{[]string{"note"}, false, false, KindPolicy{}}
 	pc := parser.NewContext()
 
 	// Create a mock alert node with header and body children
@@ -337,14 +337,14 @@ func TestAlertsParserClose(t *testing.T) {
 }
 
 func TestAlertsParserCanInterruptParagraph(t *testing.T) {
-	p := &alertParser{[]string{"note"}, false, false}
+	p := &alertParser{[]string{"note"}, false, false, KindPolicy{}}
 	if !p.CanInterruptParagraph() {
 		t.Error("Expected CanInterruptParagraph to return true")
 	}
 }
 
 func TestAlertsParserCanAcceptIndentedLine(t *testing.T) {
-	p := &alertParser{[]string{"note"}, false, false}
+	p := &alertParser{[]string{"note"}, false, false, KindPolicy{}}
 	if p.CanAcceptIndentedLine() {
 		t.Error("Expected CanAcceptIndentedLine to return false")
 	}
@@ -352,7 +352,7 @@ func TestAlertsParserCanAcceptIndentedLine(t *testing.T) {
 
 func TestAlertsParserIntegration(t *testing.T) {
 	// Test the parser with a complete alert structure
-	p := &alertParser{[]string{"warning"}, true, true}
+	p := &alertParser{[]string{"warning"}, true, true, KindPolicy{}}
 	pc := parser.NewContext()
 	parent := gast.NewDocument()
 
@@ -392,6 +392,35 @@ func TestAlertsParserIntegration(t *testing.T) {
 	}
 }
 
+func TestAlertsParserKindPolicy(t *testing.T) {
+	testCases := []struct {
+		name     string
+		custom   bool
+		policy   KindPolicy
+		input    string
+		expected bool
+	}{
+		{"Denied kind with custom alerts", true, KindPolicy{Deny: []string{"warning"}}, "> [!warning]", false},
+		{"Denied kind with noicon prefix", true, KindPolicy{Deny: []string{"warning"}}, "> [!noicon-warning]", false},
+		{"Other kind not denied", true, KindPolicy{Deny: []string{"warning"}}, "> [!custom]", true},
+		{"Allowed kind", true, KindPolicy{Allow: []string{"note"}}, "> [!note]", true},
+		{"Kind outside allow list", true, KindPolicy{Allow: []string{"note"}}, "> [!custom]", false},
+		{"Allowed kind without icon in strict mode", false, KindPolicy{Allow: []string{"security"}}, "> [!security]", true},
+		{"Icon kind outside allow list in strict mode", false, KindPolicy{Allow: []string{"security"}}, "> [!warning]", false},
+		{"Deny wins over allow", true, KindPolicy{Allow: []string{"note"}, Deny: []string{"note"}}, "> [!note]", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewAlertsParserWithPolicy([]string{"note", "warning"}, true, tc.custom, tc.policy)
+			node, _ := p.Open(gast.NewDocument(), text.NewReader([]byte(tc.input)), parser.NewContext())
+			if (node != nil) != tc.expected {
+				t.Errorf("Expected alert=%v for %q, got node %v", tc.expected, tc.input, node)
+			}
+		})
+	}
+}
+
 func TestRegexMatching(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -472,3 +501,39 @@ func TestRegexMatching(t *testing.T) {
 		})
 	}
 }
+
+func TestAlertsParserDefaultFolds(t *testing.T) {
+	folds := map[string]string{"note": "open", "warning": "closed", "tip": "none"}
+
+	testCases := []struct {
+		name       string
+		folding    bool
+		input      string
+		shouldFold bool
+		closed     bool
+	}{
+		{"Default open", true, "> [!note]", true, false},
+		{"Default closed", true, "> [!warning]", true, true},
+		{"Default closed with noicon prefix", true, "> [!noicon-warning]", true, true},
+		{"Default none", true, "> [!tip]", false, false},
+		{"No default", true, "> [!custom]", false, false},
+		{"Markdown wins over the default", true, "> [!warning]+", true, false},
+		{"Ignored without folding", false, "> [!warning]", false, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewAlertsParserWithPolicy([]string{"note", "warning", "tip"}, tc.folding, true, KindPolicy{Folds: folds})
+			node, _ := p.Open(gast.NewDocument(), text.NewReader([]byte(tc.input)), parser.NewContext())
+			if node == nil {
+				t.Fatalf("Expected an alert for %q", tc.input)
+			}
+			if shouldFold, _ := node.AttributeString("shouldfold"); shouldFold != tc.shouldFold {
+				t.Errorf("Expected shouldfold=%v, got %v", tc.shouldFold, shouldFold)
+			}
+			if closed, _ := node.AttributeString("closed"); closed != tc.closed {
+				t.Errorf("Expected closed=%v, got %v", tc.closed, closed)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package parser
+
+import (
+	"slices"
+
+	"github.com/zmtcreative/gm-alert-callouts/internal/ast"
+	"github.com/zmtcreative/gm-alert-callouts/internal/constants"
+	gast "github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+type spriteTransformer struct{}
+
+var defaultSpriteTransformer = &spriteTransformer{}
+
+// NewSpriteTransformer returns an AST transformer that appends a single AlertsSprite node to
+// documents that contain alerts, listing the kinds used so the renderer can write their symbols.
+func NewSpriteTransformer() parser.ASTTransformer {
+	return defaultSpriteTransformer
+}
+
+func (t *spriteTransformer) Transform(doc *gast.Document, reader text.Reader, pc parser.Context) {
+	var kinds []string
+	_ = gast.Walk(doc, func(n gast.Node, entering bool) (gast.WalkStatus, error) {
+		if !entering || n.Kind() != constants.KindAlertsHeader {
+			return gast.WalkContinue, nil
+		}
+		if kind, ok := n.AttributeString("kind"); ok {
+			if s, isStr := kind.(string); isStr && !slices.Contains(kinds, s) {
+				kinds = append(kinds, s)
+			}
+		}
+		return gast.WalkContinue, nil
+	})
+
+	if len(kinds) > 0 {
+		doc.AppendChild(doc, ast.NewAlertsSprite(kinds))
+	}
+}
@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/zmtcreative/gm-alert-callouts/internal/ast"
+	"github.com/zmtcreative/gm-alert-callouts/internal/constants"
+	gast "github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+func parseWithSpriteTransformer(source string) gast.Node {
+	p := parser.NewParser(
+		parser.WithBlockParsers(append(parser.DefaultBlockParsers(),
+			util.Prioritized(NewAlertsParser(nil, true, true), 799),
+			util.Prioritized(NewAlertsHeaderParser(), 799),
+		)...),
+		parser.WithInlineParsers(parser.DefaultInlineParsers()...),
+		parser.WithParagraphTransformers(parser.DefaultParagraphTransformers()...),
+		parser.WithASTTransformers(util.Prioritized(NewSpriteTransformer(), 999)),
+	)
+	return p.Parse(text.NewReader([]byte(source)))
+}
+
+func TestSpriteTransformer(t *testing.T) {
+	t.Run("Appends one sprite with the kinds in order", func(t *testing.T) {
+		doc := parseWithSpriteTransformer("> [!tip]\n> a\n\n> [!NOTE]\n> > [!tip]\n> > b\n> > > [!noicon-warning]\n")
+
+		last := doc.LastChild()
+		if last == nil || last.Kind() != constants.KindAlertsSprite {
+			t.Fatalf("Expected the last child to be AlertsSprite, got %v", last)
+		}
+		kinds := last.(*ast.AlertsSprite).Kinds
+		if len(kinds) != 3 || kinds[0] != "tip" || kinds[1] != "NOTE" || kinds[2] != "warning" {
+			t.Errorf("Expected kinds [tip NOTE warning], got %v", kinds)
+		}
+
+		count := 0
+		for c := doc.FirstChild(); c != nil; c = c.NextSibling() {
+			if c.Kind() == constants.KindAlertsSprite {
+				count++
+			}
+		}
+		if count != 1 {
+			t.Errorf("Expected exactly one sprite, got %d", count)
+		}
+	})
+
+	t.Run("Documents without alerts are left alone", func(t *testing.T) {
+		doc := parseWithSpriteTransformer("Just a paragraph\n\n> a quote\n")
+		if last := doc.LastChild(); last != nil && last.Kind() == constants.KindAlertsSprite {
+			t.Error("Expected no sprite for a document without alerts")
+		}
+	})
+}
@@ -18,6 +18,10 @@ type AlertsHTMLRenderer struct {
 	CustomAlertsEnabled bool
 	DefaultIcons        int
 	AllowNOICON         bool
+	Extends             map[string]string // Parent kind per kind; callouts also get the CSS class of each parent
+	Aliases             map[string]string // Alias to primary kind map, used when CanonicalKinds is set
+	CanonicalKinds      bool              // Whether aliases render as their primary kind, with the alias in data-callout-alias
+	IconSet             string            // Name of the icon set for the iconset-<name> class (from DefaultIcons when empty)
 }
 
 func NewAlertsHTMLRenderer(icons map[string]string, foldingEnabled bool, defaultIcons int, customAlertsEnabled bool, allowNOICON bool, opts ...html.Option) renderer.NodeRenderer {
@@ -65,13 +69,23 @@ func (r *AlertsHTMLRenderer) renderAlerts(w util.BufWriter, source []byte, node
 	}
 
 	iconset := ""
-	switch r.DefaultIcons {
-	case constants.ICONS_GFM:
-		iconset = " iconset-gfm"
-	case constants.ICONS_HYBRID:
-		iconset = " iconset-hybrid"
-	case constants.ICONS_OBSIDIAN:
-		iconset = " iconset-obsidian"
+	if name := iconSetName(r.DefaultIcons); name != "" {
+		iconset = " iconset-" + name
+	}
+	if r.IconSet != "" {
+		iconset = " iconset-" + r.IconSet
+	}
+
+	// With canonical kinds, an alias renders as its primary kind and keeps its own spelling in data-callout-alias.
+	alias := ""
+	if primary, ok := r.Aliases[alertType]; ok && r.CanonicalKinds {
+		alias = fmt.Sprintf(` data-callout-alias="%s"`, alertType)
+		alertType = primary
+	}
+
+	parents := ""
+	for _, parent := range ParentKinds(alertType, r.Extends) {
+		parents += " callout-" + parent
 	}
 
 	startHTML := ""
@@ -79,10 +93,10 @@ func (r *AlertsHTMLRenderer) renderAlerts(w util.BufWriter, source []byte, node
 	var _ = icon
 
 	if r.FoldingEnabled && shouldFold {
-		startHTML = fmt.Sprintf(`<details class="callout callout-foldable callout-%s%s" data-callout="%s"%s>`, alertType, iconset, alertType, open)
+		startHTML = fmt.Sprintf(`<details class="callout callout-foldable callout-%s%s%s" data-callout="%s"%s%s>`, alertType, parents, iconset, alertType, alias, open)
 		endHTML = "\n</details>\n"
 	} else {
-		startHTML = fmt.Sprintf(`<div class="callout callout-%s%s" data-callout="%s">`, alertType, iconset, alertType)
+		startHTML = fmt.Sprintf(`<div class="callout callout-%s%s%s" data-callout="%s"%s>`, alertType, parents, iconset, alertType, alias)
 		endHTML = "\n</div>\n"
 	}
 
@@ -374,3 +374,75 @@ type mockNodeRendererFuncRegisterer struct {
 func (m *mockNodeRendererFuncRegisterer) Register(kind gast.NodeKind, fn renderer.NodeRendererFunc) {
 	m.registrations[kind] = fn
 }
+
+func TestAlertsHTMLRendererExtends(t *testing.T) {
+	r := NewAlertsHTMLRenderer(map[string]string{"security": "<svg></svg>"}, true, constants.ICONS_HYBRID, true, true).(*AlertsHTMLRenderer)
+	r.Extends = map[string]string{"security": "warning"}
+
+	node := ast.NewAlerts()
+	node.SetAttributeString("kind", []byte("security"))
+	node.SetAttributeString("closed", false)
+	node.SetAttributeString("shouldfold", false)
+
+	writer := newMockBufWriter()
+	if _, err := r.renderAlerts(writer, []byte{}, node, true); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `<div class="callout callout-security callout-warning iconset-hybrid" data-callout="security">`
+	if writer.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, writer.String())
+	}
+}
+
+func TestAlertsHTMLRendererCanonicalKinds(t *testing.T) {
+	r := NewAlertsHTMLRenderer(map[string]string{"note": "<svg/>", "info": "<svg/>"}, true, constants.ICONS_NONE, true, true).(*AlertsHTMLRenderer)
+	r.Aliases = map[string]string{"info": "note"}
+
+	render := func(kind string, fold bool) string {
+		node := ast.NewAlerts()
+		node.SetAttributeString("kind", []byte(kind))
+		node.SetAttributeString("closed", true)
+		node.SetAttributeString("shouldfold", fold)
+		writer := newMockBufWriter()
+		if _, err := r.renderAlerts(writer, []byte{}, node, true); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return writer.String()
+	}
+
+	if got := render("Info", false); got != `<div class="callout callout-info" data-callout="info">` {
+		t.Errorf("Expected the alias as written when disabled, got %q", got)
+	}
+
+	r.CanonicalKinds = true
+	if got := render("Info", false); got != `<div class="callout callout-note" data-callout="note" data-callout-alias="info">` {
+		t.Errorf("Expected the canonical kind, got %q", got)
+	}
+	if got := render("info", true); got != `<details class="callout callout-foldable callout-note" data-callout="note" data-callout-alias="info">` {
+		t.Errorf("Expected the canonical kind on a foldable callout, got %q", got)
+	}
+	if got := render("note", false); got != `<div class="callout callout-note" data-callout="note">` {
+		t.Errorf("Expected no alias attribute for a primary kind, got %q", got)
+	}
+}
+
+func TestAlertsHTMLRendererIconSetName(t *testing.T) {
+	r := NewAlertsHTMLRenderer(map[string]string{"note": "<svg/>"}, true, constants.ICONS_HYBRID, true, true).(*AlertsHTMLRenderer)
+	r.IconSet = "brand"
+
+	node := ast.NewAlerts()
+	node.SetAttributeString("kind", []byte("note"))
+	node.SetAttributeString("closed", false)
+	node.SetAttributeString("shouldfold", false)
+
+	writer := newMockBufWriter()
+	if _, err := r.renderAlerts(writer, []byte{}, node, true); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `<div class="callout callout-note iconset-brand" data-callout="note">`
+	if writer.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, writer.String())
+	}
+}
@@ -0,0 +1,110 @@
+package renderer
+
+import (
+	"strings"
+
+	"github.com/zmtcreative/gm-alert-callouts/internal/constants"
+	gast "github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+// AsciiDocAdmonitions maps the five GFM kinds onto the AsciiDoc admonition labels of the same name.
+var AsciiDocAdmonitions = map[string]string{
+	"note":      "NOTE",
+	"tip":       "TIP",
+	"important": "IMPORTANT",
+	"warning":   "WARNING",
+	"caution":   "CAUTION",
+}
+
+// AsciiDocConfig holds the options for the AsciiDoc renderer.
+type AsciiDocConfig struct {
+	Aliases     map[string]string // Alias to primary kind map, used to resolve aliases to a mapped kind
+	GFMKinds    map[string]string // GFM kind per kind, used when the map names neither the kind nor its primary
+	Admonitions map[string]string // Admonition label per kind (AsciiDocAdmonitions when nil)
+	Fallback    string            // Admonition label for kinds without a mapping
+}
+
+// AlertsAsciiDocRenderer renders the alert nodes as AsciiDoc admonition blocks:
+//
+//	[NOTE]
+//	.Title
+//	====
+//	...
+//	====
+//
+// Kinds are resolved through the icon map so aliases use the label of their primary kind.
+// When the label differs from the kind, the kind is kept as the block's role.
+// The body is left to the renderers of the core nodes.
+type AlertsAsciiDocRenderer struct {
+	AsciiDocConfig
+}
+
+// NewAlertsAsciiDocRenderer returns an AsciiDoc renderer for the Alerts, AlertsHeader and AlertsBody nodes.
+func NewAlertsAsciiDocRenderer(config AsciiDocConfig) renderer.NodeRenderer {
+	if config.Admonitions == nil {
+		config.Admonitions = AsciiDocAdmonitions
+	}
+	if config.Fallback == "" {
+		config.Fallback = "NOTE"
+	}
+	return &AlertsAsciiDocRenderer{AsciiDocConfig: config}
+}
+
+func (r *AlertsAsciiDocRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(constants.KindAlerts, r.renderAlerts)
+	reg.Register(constants.KindAlertsHeader, r.renderAlertsHeader)
+	reg.Register(constants.KindAlertsBody, r.renderAlertsBody)
+}
+
+func (r *AlertsAsciiDocRenderer) renderAlerts(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	// Nested blocks need a longer delimiter than the block they are in
+	delimiter := strings.Repeat("=", 4+alertDepth(node))
+
+	if !entering {
+		w.WriteString(delimiter + "\n")
+		return gast.WalkContinue, nil
+	}
+
+	attrs := readAlertAttributes(node)
+	label := r.label(attrs.Kind)
+	w.WriteString("[" + label)
+	if strings.ToLower(label) != attrs.Kind {
+		w.WriteString(",role=" + attrs.Kind)
+	}
+	w.WriteString("]\n")
+	if title := alertTitle(node, source); title != "" {
+		w.WriteString("." + title + "\n")
+	}
+	w.WriteString(delimiter + "\n")
+	return gast.WalkContinue, nil
+}
+
+// renderAlertsHeader skips the header, since the title is written as the block title.
+func (r *AlertsAsciiDocRenderer) renderAlertsHeader(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	return gast.WalkSkipChildren, nil
+}
+
+func (r *AlertsAsciiDocRenderer) renderAlertsBody(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	return gast.WalkContinue, nil
+}
+
+// label returns the admonition label for kind, falling back to the configured label.
+func (r *AlertsAsciiDocRenderer) label(kind string) string {
+	if label := r.Admonitions[resolveKind(kind, r.Aliases, r.GFMKinds, r.Admonitions)]; label != "" {
+		return label
+	}
+	return r.Fallback
+}
+
+// alertDepth returns the number of Alerts nodes that node is nested in.
+func alertDepth(node gast.Node) int {
+	depth := 0
+	for p := node.Parent(); p != nil; p = p.Parent() {
+		if p.Kind() == constants.KindAlerts {
+			depth++
+		}
+	}
+	return depth
+}
@@ -0,0 +1,57 @@
+package renderer
+
+import (
+	"testing"
+)
+
+func TestAlertsAsciiDocRenderer(t *testing.T) {
+	aliases := map[string]string{"hint": "tip"}
+
+	testCases := []struct {
+		name     string
+		config   AsciiDocConfig
+		input    string
+		expected string
+	}{
+		{
+			name:     "GFM kind",
+			config:   AsciiDocConfig{Aliases: aliases},
+			input:    "> [!WARNING]\n> Body",
+			expected: "[WARNING]\n====\n====\n",
+		},
+		{
+			name:     "Custom title",
+			config:   AsciiDocConfig{Aliases: aliases},
+			input:    "> [!tip] Pro tip\n> Body",
+			expected: "[TIP]\n.Pro tip\n====\n====\n",
+		},
+		{
+			name:     "Alias resolves through the icon map",
+			config:   AsciiDocConfig{Aliases: aliases},
+			input:    "> [!hint]\n> Body",
+			expected: "[TIP,role=hint]\n====\n====\n",
+		},
+		{
+			name:     "Other kinds use the fallback",
+			config:   AsciiDocConfig{Aliases: aliases, Fallback: "CAUTION"},
+			input:    "> [!bug]\n> Body",
+			expected: "[CAUTION,role=bug]\n====\n====\n",
+		},
+		{
+			name:     "Nested blocks use longer delimiters",
+			config:   AsciiDocConfig{Aliases: aliases},
+			input:    "> [!note]\n> > [!tip]\n> > Body",
+			expected: "[NOTE]\n====\n[TIP]\n=====\n=====\n====\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewAlertsAsciiDocRenderer(tc.config)
+			result := renderAlertsDocument(t, r, tc.input)
+			if result != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package renderer
+
+import (
+	"github.com/zmtcreative/gm-alert-callouts/internal/constants"
+	gast "github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// ConfluenceMacros maps the primary kinds of the built-in icon sets onto the Confluence
+// info (blue), tip (green), note (yellow) and warning (red) macros.
+var ConfluenceMacros = map[string]string{
+	"note":      "info",
+	"info":      "info",
+	"abstract":  "info",
+	"summary":   "info",
+	"todo":      "info",
+	"question":  "info",
+	"example":   "info",
+	"quote":     "info",
+	"scroll":    "info",
+	"tip":       "tip",
+	"success":   "tip",
+	"important": "note",
+	"warning":   "note",
+	"caution":   "warning",
+	"failure":   "warning",
+	"danger":    "warning",
+	"bug":       "warning",
+}
+
+// ConfluenceConfig holds the options for the Confluence storage-format renderer.
+type ConfluenceConfig struct {
+	Aliases        map[string]string // Alias to primary kind map, used to resolve aliases to a mapped kind
+	GFMKinds       map[string]string // GFM kind per kind, used when the map names neither the kind nor its primary
+	FoldingEnabled bool              // Whether folded callouts become 'expand' macros
+	AllowNOICON    bool              // Whether a 'noicon-' prefix hides the macro icon
+	Macros         map[string]string // Macro name per kind (ConfluenceMacros when nil)
+	Fallback       string            // Macro name for kinds without a mapping
+}
+
+// AlertsConfluenceRenderer renders the alert nodes as Confluence storage-format macros.
+// Callouts become info/tip/note/warning macros and folded callouts become 'expand' macros.
+// The body is left to the renderers of the core nodes, since storage format uses XHTML for it.
+type AlertsConfluenceRenderer struct {
+	ConfluenceConfig
+	titleCaser cases.Caser
+}
+
+// NewAlertsConfluenceRenderer returns a Confluence renderer for the Alerts, AlertsHeader and AlertsBody nodes.
+func NewAlertsConfluenceRenderer(config ConfluenceConfig) renderer.NodeRenderer {
+	return newAlertsConfluenceRenderer(config, DetectLanguageTag())
+}
+
+// newAlertsConfluenceRenderer is an unexported constructor that allows injecting a language tag for tests.
+func newAlertsConfluenceRenderer(config ConfluenceConfig, tag language.Tag) *AlertsConfluenceRenderer {
+	if config.Macros == nil {
+		config.Macros = ConfluenceMacros
+	}
+	if config.Fallback == "" {
+		config.Fallback = "info"
+	}
+	return &AlertsConfluenceRenderer{
+		ConfluenceConfig: config,
+		titleCaser:       cases.Title(tag, cases.Compact),
+	}
+}
+
+func (r *AlertsConfluenceRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(constants.KindAlerts, r.renderAlerts)
+	reg.Register(constants.KindAlertsHeader, r.renderAlertsHeader)
+	reg.Register(constants.KindAlertsBody, r.renderAlertsBody)
+}
+
+func (r *AlertsConfluenceRenderer) renderAlerts(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	if !entering {
+		w.WriteString("</ac:rich-text-body></ac:structured-macro>\n")
+		return gast.WalkContinue, nil
+	}
+
+	attrs := readAlertAttributes(node)
+	title := alertTitle(node, source)
+
+	if r.FoldingEnabled && attrs.ShouldFold {
+		// The expand macro always needs a title to click on
+		if title == "" {
+			title = r.titleCaser.String(attrs.Kind)
+		}
+		w.WriteString(`<ac:structured-macro ac:name="expand">`)
+	} else {
+		w.WriteString(`<ac:structured-macro ac:name="` + EscapeXML(r.macro(attrs.Kind)) + `">`)
+		if r.AllowNOICON && attrs.NoIcon {
+			w.WriteString(`<ac:parameter ac:name="icon">false</ac:parameter>`)
+		}
+	}
+	if title != "" {
+		w.WriteString(`<ac:parameter ac:name="title">` + EscapeXML(title) + `</ac:parameter>`)
+	}
+	w.WriteString("<ac:rich-text-body>\n")
+	return gast.WalkContinue, nil
+}
+
+// renderAlertsHeader skips the header, since the title is written as the macro's title parameter.
+func (r *AlertsConfluenceRenderer) renderAlertsHeader(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	return gast.WalkSkipChildren, nil
+}
+
+func (r *AlertsConfluenceRenderer) renderAlertsBody(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	return gast.WalkContinue, nil
+}
+
+// macro returns the macro name for kind, falling back to the configured macro.
+func (r *AlertsConfluenceRenderer) macro(kind string) string {
+	if macro := r.Macros[resolveKind(kind, r.Aliases, r.GFMKinds, r.Macros)]; macro != "" {
+		return macro
+	}
+	return r.Fallback
+}
@@ -0,0 +1,71 @@
+package renderer
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestAlertsConfluenceRenderer(t *testing.T) {
+	aliases := map[string]string{"danger": "caution"}
+
+	testCases := []struct {
+		name     string
+		config   ConfluenceConfig
+		input    string
+		expected string
+	}{
+		{
+			name:     "Note becomes info macro",
+			config:   ConfluenceConfig{Aliases: aliases},
+			input:    "> [!NOTE]\n> Body",
+			expected: "<ac:structured-macro ac:name=\"info\"><ac:rich-text-body>\n</ac:rich-text-body></ac:structured-macro>\n",
+		},
+		{
+			name:     "Custom title is escaped",
+			config:   ConfluenceConfig{Aliases: aliases},
+			input:    "> [!tip] Q&A\n> Body",
+			expected: "<ac:structured-macro ac:name=\"tip\"><ac:parameter ac:name=\"title\">Q&amp;A</ac:parameter><ac:rich-text-body>\n</ac:rich-text-body></ac:structured-macro>\n",
+		},
+		{
+			name:     "Alias resolves through the icon map",
+			config:   ConfluenceConfig{Aliases: aliases, Macros: map[string]string{"caution": "warning"}},
+			input:    "> [!danger]\n> Body",
+			expected: "<ac:structured-macro ac:name=\"warning\"><ac:rich-text-body>\n</ac:rich-text-body></ac:structured-macro>\n",
+		},
+		{
+			name:     "Other kinds use the fallback",
+			config:   ConfluenceConfig{Aliases: aliases, Fallback: "note"},
+			input:    "> [!custom]\n> Body",
+			expected: "<ac:structured-macro ac:name=\"note\"><ac:rich-text-body>\n</ac:rich-text-body></ac:structured-macro>\n",
+		},
+		{
+			name:     "NOICON hides the macro icon",
+			config:   ConfluenceConfig{Aliases: aliases, AllowNOICON: true},
+			input:    "> [!noicon-warning]\n> Body",
+			expected: "<ac:structured-macro ac:name=\"note\"><ac:parameter ac:name=\"icon\">false</ac:parameter><ac:rich-text-body>\n</ac:rich-text-body></ac:structured-macro>\n",
+		},
+		{
+			name:     "Folded callout becomes expand macro",
+			config:   ConfluenceConfig{Aliases: aliases, FoldingEnabled: true},
+			input:    "> [!warning]-\n> Body",
+			expected: "<ac:structured-macro ac:name=\"expand\"><ac:parameter ac:name=\"title\">Warning</ac:parameter><ac:rich-text-body>\n</ac:rich-text-body></ac:structured-macro>\n",
+		},
+		{
+			name:     "Fold markers ignored when folding is disabled",
+			config:   ConfluenceConfig{Aliases: aliases},
+			input:    "> [!warning]+ Open\n> Body",
+			expected: "<ac:structured-macro ac:name=\"note\"><ac:parameter ac:name=\"title\">Open</ac:parameter><ac:rich-text-body>\n</ac:rich-text-body></ac:structured-macro>\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newAlertsConfluenceRenderer(tc.config, language.English)
+			result := renderAlertsDocument(t, r, tc.input)
+			if result != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package renderer
+
+import (
+	"strings"
+
+	"github.com/zmtcreative/gm-alert-callouts/internal/constants"
+	gast "github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+// DocBookAdmonitions maps the five GFM kinds onto the DocBook admonition elements of the same name.
+var DocBookAdmonitions = map[string]string{
+	"note":      "note",
+	"tip":       "tip",
+	"important": "important",
+	"warning":   "warning",
+	"caution":   "caution",
+}
+
+// DocBookConfig holds the options for the DocBook renderer.
+type DocBookConfig struct {
+	Aliases  map[string]string // Alias to primary kind map, used to resolve aliases to a mapped kind
+	GFMKinds map[string]string // GFM kind per kind, used when the map names neither the kind nor its primary
+	Elements map[string]string // Admonition element per kind (DocBookAdmonitions when nil)
+	Fallback string            // Admonition element for kinds without a mapping
+}
+
+// AlertsDocBookRenderer renders the alert nodes as DocBook admonition elements.
+// Kinds are resolved through the icon map so aliases use the element of their primary kind.
+// When the element name differs from the kind, the kind is kept in a 'role' attribute.
+// The body is left to the renderers of the core nodes.
+type AlertsDocBookRenderer struct {
+	DocBookConfig
+}
+
+// NewAlertsDocBookRenderer returns a DocBook renderer for the Alerts, AlertsHeader and AlertsBody nodes.
+func NewAlertsDocBookRenderer(config DocBookConfig) renderer.NodeRenderer {
+	if config.Elements == nil {
+		config.Elements = DocBookAdmonitions
+	}
+	if config.Fallback == "" {
+		config.Fallback = "note"
+	}
+	return &AlertsDocBookRenderer{DocBookConfig: config}
+}
+
+func (r *AlertsDocBookRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(constants.KindAlerts, r.renderAlerts)
+	reg.Register(constants.KindAlertsHeader, r.renderAlertsHeader)
+	reg.Register(constants.KindAlertsBody, r.renderAlertsBody)
+}
+
+func (r *AlertsDocBookRenderer) renderAlerts(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	attrs := readAlertAttributes(node)
+	element := r.element(attrs.Kind)
+
+	if !entering {
+		w.WriteString("</" + element + ">\n")
+		return gast.WalkContinue, nil
+	}
+
+	w.WriteString("<" + element)
+	if element != attrs.Kind {
+		w.WriteString(` role="` + EscapeXML(attrs.Kind) + `"`)
+	}
+	w.WriteString(">\n")
+	return gast.WalkContinue, nil
+}
+
+// renderAlertsHeader writes the custom title, if there is one, as the admonition's <title>.
+func (r *AlertsDocBookRenderer) renderAlertsHeader(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	if entering {
+		if title := alertTitle(node, source); title != "" {
+			w.WriteString("<title>" + EscapeXML(title) + "</title>\n")
+		}
+	}
+	return gast.WalkSkipChildren, nil
+}
+
+func (r *AlertsDocBookRenderer) renderAlertsBody(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	return gast.WalkContinue, nil
+}
+
+// element returns the admonition element for kind, falling back to the configured element.
+func (r *AlertsDocBookRenderer) element(kind string) string {
+	if element := r.Elements[resolveKind(kind, r.Aliases, r.GFMKinds, r.Elements)]; element != "" {
+		return element
+	}
+	return r.Fallback
+}
+
+var xmlEscapes = strings.NewReplacer(
+	`&`, `&amp;`,
+	`<`, `&lt;`,
+	`>`, `&gt;`,
+	`"`, `&quot;`,
+	`'`, `&apos;`,
+)
+
+// EscapeXML escapes the characters that have a special meaning in XML text and attribute values.
+func EscapeXML(s string) string {
+	return xmlEscapes.Replace(s)
+}
@@ -0,0 +1,63 @@
+package renderer
+
+import (
+	"testing"
+)
+
+func TestAlertsDocBookRenderer(t *testing.T) {
+	aliases := map[string]string{"hint": "tip"}
+
+	testCases := []struct {
+		name     string
+		config   DocBookConfig
+		input    string
+		expected string
+	}{
+		{
+			name:     "GFM kind maps natively",
+			config:   DocBookConfig{Aliases: aliases},
+			input:    "> [!WARNING]\n> Body",
+			expected: "<warning>\n</warning>\n",
+		},
+		{
+			name:     "Custom title",
+			config:   DocBookConfig{Aliases: aliases},
+			input:    "> [!tip] If a < b & c\n> Body",
+			expected: "<tip>\n<title>If a &lt; b &amp; c</title>\n</tip>\n",
+		},
+		{
+			name:     "Alias resolves through the icon map",
+			config:   DocBookConfig{Aliases: aliases},
+			input:    "> [!hint]\n> Body",
+			expected: "<tip role=\"hint\">\n</tip>\n",
+		},
+		{
+			name:     "Other kinds use the fallback",
+			config:   DocBookConfig{Aliases: aliases, Fallback: "important"},
+			input:    "> [!bug]\n> Body",
+			expected: "<important role=\"bug\">\n</important>\n",
+		},
+		{
+			name:     "Custom mapping",
+			config:   DocBookConfig{Aliases: aliases, Elements: map[string]string{"bug": "caution"}},
+			input:    "> [!bug]\n> Body",
+			expected: "<caution role=\"bug\">\n</caution>\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewAlertsDocBookRenderer(tc.config)
+			result := renderAlertsDocument(t, r, tc.input)
+			if result != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestEscapeXML(t *testing.T) {
+	if got := EscapeXML(`<a href="x">'&'</a>`); got != "&lt;a href=&quot;x&quot;&gt;&apos;&amp;&apos;&lt;/a&gt;" {
+		t.Errorf("Unexpected escaping: %q", got)
+	}
+}
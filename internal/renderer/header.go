@@ -26,6 +26,11 @@ type AlertsHeaderHTMLRenderer struct {
 	CustomAlertsEnabled bool
 	DefaultIcons        int
 	AllowNOICON         bool
+	IconMode            int               // How icons are written (constants.ICON_MODE_*)
+	Aliases             map[string]string // Alias to primary kind map, so aliases share a sprite symbol
+	SpriteURL           string            // URL of an external sprite file (empty when the sprite is in the page)
+	Titles              map[string]string // Default display title per kind (the title-cased kind when missing)
+	Labels              map[string]string // Accessible label per kind for emoji icons (the title when missing)
 	titleCaser          cases.Caser
 }
 
@@ -38,6 +43,12 @@ func NewAlertsHeaderHTMLRendererWithIcons(icons Icons, foldingEnabled FoldingEna
 // NewAlertsHeaderHTMLRenderer is the public constructor used during normal program operation.
 // It attempts to get the user's locale and then calls the internal implementation 'newAlertsHeaderHTMLRenderer' below with all the necessary parameters
 func NewAlertsHeaderHTMLRenderer(icons map[string]string, foldingEnabled bool, defaultIcons int, customAlertsEnabled bool, allowNOICON bool, opts ...html.Option) renderer.NodeRenderer {
+	return newAlertsHeaderHTMLRenderer(icons, foldingEnabled, defaultIcons, customAlertsEnabled, allowNOICON, DetectLanguageTag(), opts...)
+}
+
+// DetectLanguageTag returns the language tag of the user's OS-level locale.
+// It is used to title-case alert kinds that are rendered as titles.
+func DetectLanguageTag() language.Tag {
 	// Detect the user's OS-level locale.
 	userLocale, err := locale.GetLocale()
 	if err != nil {
@@ -50,8 +61,7 @@ func NewAlertsHeaderHTMLRenderer(icons map[string]string, foldingEnabled bool, d
 	// which is a perfect default for title casing. The cases package will
 	// handle this gracefully.
 	tag, _ := language.Parse(userLocale)
-
-	return newAlertsHeaderHTMLRenderer(icons, foldingEnabled, defaultIcons, customAlertsEnabled, allowNOICON, tag, opts...)
+	return tag
 }
 
 // newAlertsHeaderHTMLRenderer is an unexported constructor that allows injecting a language tag.
@@ -113,29 +123,18 @@ func (r *AlertsHeaderHTMLRenderer) renderAlertsHeader(w util.BufWriter, source [
 		// use CSS on this spot in the output (not sure it's necessary, but just being thorough for now).
 		startHTML += `<span class="callout-title-noicon" style="display: none;"></span>`
 	} else {
-		// if the icon value is not empty, use the icon
+		// if the kind has an icon, use the icon
 		// else if custom alerts are enabled, use a fallback icon from 'constants.FALLBACK_ICON_LIST'
-		if icon != "" {
-			startHTML += icon
+		if iconKind := headerIconKind(kind, r.Icons, r.CustomAlertsEnabled); iconKind != "" {
+			startHTML += r.iconHTML(iconKind)
 		} else if r.CustomAlertsEnabled {
-			found := false
-			for _, v := range constants.FALLBACK_ICON_LIST {
-				deficon, ok := r.Icons[v]
-				if ok {
-					startHTML += deficon
-					found = true
-					break
-				}
-			}
 			// If all else fails, generate an empty span as a placeholder for the icon
 			// Note: This should never happen with the built-in iconsets.
 			//       However, for custom iconsets provided by the user, they may not use
 			//       alert names that match the predefined set of fallbacks in '
 			//       constants.FALLBACK_ICON_LIST'. To be consistent, I think
 			//       SOMETHING should be output where the icon would go.
-			if !found {
-				startHTML += `<span class="callout-title-noicon" style="display: none;"></span>`
-			}
+			startHTML += `<span class="callout-title-noicon" style="display: none;"></span>`
 		}
 	}
 
@@ -148,7 +147,11 @@ func (r *AlertsHeaderHTMLRenderer) renderAlertsHeader(w util.BufWriter, source [
 		// If title isn't set, use kind for the title
 		// NOTE: if title IS set, it is rendered separately as a text node when we 'WalkContinue' at the end
 		if !hasTitle {
-			startHTML += r.titleCaser.String(kind)
+			if title, ok := r.Titles[kind]; ok {
+				startHTML += string(util.EscapeHTML([]byte(title)))
+			} else {
+				startHTML += r.titleCaser.String(kind)
+			}
 		}
 	} else {
 		// If we've gotten here, this is an invalid callout
@@ -169,3 +172,34 @@ func (r *AlertsHeaderHTMLRenderer) renderAlertsHeader(w util.BufWriter, source [
 	}
 	return gast.WalkContinue, nil
 }
+
+// iconHTML returns the markup for the icon of iconKind according to the icon mode.
+// Icons that cannot be referenced from a sprite or drawn by the stylesheet are written inline,
+// with image, icon font and emoji sources as <img>, <i> and <span role="img"> elements.
+func (r *AlertsHeaderHTMLRenderer) iconHTML(iconKind string) string {
+	icon := r.Icons[iconKind]
+	switch r.IconMode {
+	case constants.ICON_MODE_SPRITE:
+		href := r.SpriteURL + "#" + SpriteSymbolID(spriteKind(iconKind, r.Icons, r.Aliases))
+		if ref, ok := spriteReference(icon, href); ok {
+			return ref
+		}
+	case constants.ICON_MODE_CSS:
+		if _, _, ok := splitSVG(icon); ok {
+			return ICON_CSS_SPAN
+		}
+	}
+	return IconElement(icon, r.iconLabel(iconKind))
+}
+
+// iconLabel returns the accessible label for the icon of iconKind: its label, its default title
+// or the title-cased kind.
+func (r *AlertsHeaderHTMLRenderer) iconLabel(iconKind string) string {
+	if label, ok := r.Labels[iconKind]; ok {
+		return label
+	}
+	if title, ok := r.Titles[iconKind]; ok {
+		return title
+	}
+	return r.titleCaser.String(iconKind)
+}
@@ -0,0 +1,107 @@
+package renderer
+
+import (
+	"strings"
+)
+
+// ICON_CSS_SPAN is written in place of the icon in the CSS icon mode.
+const ICON_CSS_SPAN = `<span class="callout-icon"></span>`
+
+// svgDataURIEscaper percent-encodes the characters that break an SVG inside a CSS url("data:...").
+// Double quotes become single quotes so the URI can be wrapped in double quotes.
+var svgDataURIEscaper = strings.NewReplacer(
+	`"`, `'`,
+	"%", "%25",
+	"#", "%23",
+	"<", "%3C",
+	">", "%3E",
+	"\r", "",
+	"\n", " ",
+	"\t", " ",
+)
+
+// svgDataURI returns icon as a data URI, or false when icon is not an SVG.
+// An xmlns attribute is added when missing, since browsers require it for SVG images.
+func svgDataURI(icon string) (string, bool) {
+	attrs, _, ok := splitSVG(icon)
+	if !ok {
+		return "", false
+	}
+	hasNamespace := false
+	for _, a := range attrs {
+		if a.Name == "xmlns" {
+			hasNamespace = true
+			break
+		}
+	}
+	icon = strings.TrimSpace(icon)
+	if !hasNamespace {
+		icon = `<svg xmlns="http://www.w3.org/2000/svg"` + strings.TrimPrefix(icon, "<svg")
+	}
+	return "data:image/svg+xml," + svgDataURIEscaper.Replace(icon), true
+}
+
+// iconCSSSelector returns the selector for the icon span in the header of a callout of kind.
+// Child combinators keep the rule from reaching the icons of nested callouts.
+func iconCSSSelector(kind string) string {
+	return ".callout-" + kind + " > .callout-title > .callout-icon"
+}
+
+// IconStylesheet returns a stylesheet that draws the icon spans of the CSS icon mode, with one
+// mask-image rule per icon covering every kind and alias that uses it. When customAlertsEnabled
+// is set, the base rule carries the fallback icon (see constants.FALLBACK_ICON_LIST) for kinds
+// without an icon of their own. Icons that are not SVG are left out.
+func IconStylesheet(icons map[string]string, customAlertsEnabled bool) string {
+	var sb strings.Builder
+
+	sb.WriteString(".callout-icon {\n")
+	sb.WriteString("  display: inline-block;\n")
+	sb.WriteString("  width: 1.25em;\n")
+	sb.WriteString("  height: 1.25em;\n")
+	sb.WriteString("  flex-shrink: 0;\n")
+	sb.WriteString("  background-color: currentColor;\n")
+	sb.WriteString("  -webkit-mask-repeat: no-repeat;\n")
+	sb.WriteString("  mask-repeat: no-repeat;\n")
+	sb.WriteString("  -webkit-mask-position: center;\n")
+	sb.WriteString("  mask-position: center;\n")
+	sb.WriteString("  -webkit-mask-size: contain;\n")
+	sb.WriteString("  mask-size: contain;\n")
+	if fallback := headerIconKind("", icons, customAlertsEnabled); fallback != "" {
+		if uri, ok := svgDataURI(icons[fallback]); ok {
+			writeMaskImage(&sb, uri)
+		}
+	}
+	sb.WriteString("}\n")
+
+	// Group the kinds that share an icon, in sorted order, so aliases end up in the rule of their primary.
+	var order []string
+	groups := make(map[string][]string)
+	for _, kind := range sortedKeys(icons) {
+		icon := icons[kind]
+		if _, ok := groups[icon]; !ok {
+			order = append(order, icon)
+		}
+		groups[icon] = append(groups[icon], kind)
+	}
+
+	for _, icon := range order {
+		uri, ok := svgDataURI(icon)
+		if !ok {
+			continue
+		}
+		selectors := make([]string, len(groups[icon]))
+		for i, kind := range groups[icon] {
+			selectors[i] = iconCSSSelector(kind)
+		}
+		sb.WriteString("\n" + strings.Join(selectors, ",\n") + " {\n")
+		writeMaskImage(&sb, uri)
+		sb.WriteString("}\n")
+	}
+
+	return sb.String()
+}
+
+func writeMaskImage(sb *strings.Builder, uri string) {
+	sb.WriteString(`  -webkit-mask-image: url("` + uri + `");` + "\n")
+	sb.WriteString(`  mask-image: url("` + uri + `");` + "\n")
+}
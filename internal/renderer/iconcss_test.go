@@ -0,0 +1,95 @@
+package renderer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zmtcreative/gm-alert-callouts/internal/constants"
+	"golang.org/x/text/language"
+)
+
+func TestSVGDataURI(t *testing.T) {
+	testCases := []struct {
+		name     string
+		icon     string
+		expected string
+		ok       bool
+	}{
+		{
+			"Escapes reserved characters",
+			`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 16 16"><path fill="#000" d="M1 1"/></svg>`,
+			`data:image/svg+xml,%3Csvg xmlns='http://www.w3.org/2000/svg' viewBox='0 0 16 16'%3E%3Cpath fill='%23000' d='M1 1'/%3E%3C/svg%3E`,
+			true,
+		},
+		{
+			"Adds a missing namespace",
+			`<svg viewBox="0 0 1 1"></svg>`,
+			`data:image/svg+xml,%3Csvg xmlns='http://www.w3.org/2000/svg' viewBox='0 0 1 1'%3E%3C/svg%3E`,
+			true,
+		},
+		{"Rejects non-SVG icons", "💡", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uri, ok := svgDataURI(tc.icon)
+			if ok != tc.ok || uri != tc.expected {
+				t.Errorf("Expected (%q, %v), got (%q, %v)", tc.expected, tc.ok, uri, ok)
+			}
+		})
+	}
+}
+
+func TestIconStylesheet(t *testing.T) {
+	icons := map[string]string{
+		"note":  `<svg viewBox="0 0 1 1"><path d="note"/></svg>`,
+		"info":  `<svg viewBox="0 0 1 1"><path d="note"/></svg>`,
+		"tip":   `<svg viewBox="0 0 1 1"><path d="tip"/></svg>`,
+		"emoji": "💡",
+	}
+
+	css := IconStylesheet(icons, true)
+
+	if !strings.HasPrefix(css, ".callout-icon {\n") {
+		t.Errorf("Expected the base rule first, got:\n%s", css)
+	}
+	grouped := ".callout-info > .callout-title > .callout-icon,\n.callout-note > .callout-title > .callout-icon {\n"
+	if !strings.Contains(css, grouped) {
+		t.Errorf("Expected aliases to share a rule, got:\n%s", css)
+	}
+	if !strings.Contains(css, ".callout-tip > .callout-title > .callout-icon {\n") {
+		t.Errorf("Expected a rule for 'tip', got:\n%s", css)
+	}
+	if strings.Contains(css, "callout-emoji") {
+		t.Errorf("Expected non-SVG icons to be left out, got:\n%s", css)
+	}
+	// base rule fallback (note) + note/info rule + tip rule, each with and without prefix
+	if n := strings.Count(css, "mask-image: url(\"data:image/svg+xml,"); n != 6 {
+		t.Errorf("Expected 6 mask-image declarations, got %d:\n%s", n, css)
+	}
+
+	if css := IconStylesheet(icons, false); strings.Count(css, "mask-image") != 4 {
+		t.Errorf("Expected no fallback icon without custom alerts, got:\n%s", css)
+	}
+}
+
+func TestAlertsHeaderCSSMode(t *testing.T) {
+	icons := map[string]string{"note": `<svg viewBox="0 0 1 1"></svg>`, "text": "!"}
+	r := newAlertsHeaderHTMLRenderer(icons, true, constants.ICONS_NONE, true, true, language.English).(*AlertsHeaderHTMLRenderer)
+	r.IconMode = constants.ICON_MODE_CSS
+
+	testCases := map[string]string{
+		"note":   `<div class="callout-title">` + "\n" + ICON_CSS_SPAN + `<p class="callout-title-text">Note`,
+		"custom": ICON_CSS_SPAN + `<p class="callout-title-text">Custom`,
+		"text":   "\n!<p",
+	}
+	for kind, expected := range testCases {
+		w := newMockBufWriter()
+		if _, err := r.renderAlertsHeader(w, nil, createMockHeaderNode(kind, false, ""), true); err != nil {
+			t.Fatalf("renderAlertsHeader returned error: %v", err)
+		}
+		if !strings.Contains(w.String(), expected) {
+			t.Errorf("Expected %q in %q", expected, w.String())
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package renderer
+
+import (
+	"html"
+	"strings"
+
+	"github.com/zmtcreative/gm-alert-callouts/internal/constants"
+)
+
+// IconElement returns the markup for an icon: image sources become an <img>, icon font class
+// lists an <i> and emoji a <span role="img"> with label as its accessible name. Any other icon is
+// returned as it is.
+func IconElement(icon string, label string) string {
+	if src, ok := strings.CutPrefix(icon, constants.ICON_PREFIX_IMAGE); ok {
+		return `<img class="callout-icon-image" src="` + html.EscapeString(strings.TrimSpace(src)) + `" alt="">`
+	}
+	if classes, ok := strings.CutPrefix(icon, constants.ICON_PREFIX_FONT); ok {
+		return `<i class="callout-icon-font ` + html.EscapeString(strings.Join(strings.Fields(classes), " ")) + `" aria-hidden="true"></i>`
+	}
+	if emoji, ok := strings.CutPrefix(icon, constants.ICON_PREFIX_EMOJI); ok {
+		return `<span class="callout-icon-emoji" role="img" aria-label="` + html.EscapeString(label) + `">` + html.EscapeString(strings.TrimSpace(emoji)) + `</span>`
+	}
+	return icon
+}
@@ -0,0 +1,52 @@
+package renderer
+
+import (
+	"testing"
+)
+
+func TestIconElement(t *testing.T) {
+	testCases := []struct {
+		name     string
+		icon     string
+		expected string
+	}{
+		{
+			name:     "SVG",
+			icon:     `<svg viewBox="0 0 24 24"></svg>`,
+			expected: `<svg viewBox="0 0 24 24"></svg>`,
+		},
+		{
+			name:     "Image URL",
+			icon:     "image:https://cdn.example.com/icons/note.png?size=24&theme=dark",
+			expected: `<img class="callout-icon-image" src="https://cdn.example.com/icons/note.png?size=24&amp;theme=dark" alt="">`,
+		},
+		{
+			name:     "Data URI",
+			icon:     "image: data:image/png;base64,iVBORw0KGgo=",
+			expected: `<img class="callout-icon-image" src="data:image/png;base64,iVBORw0KGgo=" alt="">`,
+		},
+		{
+			name:     "Icon font",
+			icon:     "font:fa-solid  fa-circle-info",
+			expected: `<i class="callout-icon-font fa-solid fa-circle-info" aria-hidden="true"></i>`,
+		},
+		{
+			name:     "Emoji",
+			icon:     "emoji:💡",
+			expected: `<span class="callout-icon-emoji" role="img" aria-label="Tip &amp; Trick">💡</span>`,
+		},
+		{
+			name:     "Plain text",
+			icon:     "💡",
+			expected: "💡",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := IconElement(tc.icon, "Tip & Trick"); result != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
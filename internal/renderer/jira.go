@@ -0,0 +1,143 @@
+package renderer
+
+import (
+	"strings"
+
+	"github.com/zmtcreative/gm-alert-callouts/internal/constants"
+	gast "github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// JiraConfig holds the options for the Jira wiki markup renderer.
+type JiraConfig struct {
+	Aliases     map[string]string // Alias to primary kind map, used to resolve aliases to a mapped kind
+	GFMKinds    map[string]string // GFM kind per kind, used when the map names neither the kind nor its primary
+	AllowNOICON bool              // Whether a 'noicon-' prefix hides the macro icon
+	Macros      map[string]string // Macro (info, tip, note or warning) per kind (ConfluenceMacros when nil)
+	Panels      bool              // Whether every callout is written as a panel instead of a macro
+	Colors      map[string]string // Hex border color per kind for panels (constants.KIND_COLOR when nil)
+	Width       int               // Column to wrap paragraphs at (0 disables wrapping)
+}
+
+// AlertsJiraRenderer renders the alert nodes as Jira wiki markup.
+// Kinds with a macro mapping are written as `{note:title=…}…{note}`, all other kinds as
+// `{panel:title=…|borderColor=…}…{panel}` using the kind's accent color.
+// The body is written as plain text, since goldmark has no Jira renderer for the core nodes.
+type AlertsJiraRenderer struct {
+	JiraConfig
+	titleCaser cases.Caser
+}
+
+// NewAlertsJiraRenderer returns a Jira wiki markup renderer for the Alerts, AlertsHeader and AlertsBody nodes.
+func NewAlertsJiraRenderer(config JiraConfig) renderer.NodeRenderer {
+	return newAlertsJiraRenderer(config, DetectLanguageTag())
+}
+
+// newAlertsJiraRenderer is an unexported constructor that allows injecting a language tag for tests.
+func newAlertsJiraRenderer(config JiraConfig, tag language.Tag) *AlertsJiraRenderer {
+	if config.Macros == nil {
+		config.Macros = ConfluenceMacros
+	}
+	if config.Colors == nil {
+		config.Colors = constants.KIND_COLOR
+	}
+	return &AlertsJiraRenderer{
+		JiraConfig: config,
+		titleCaser: cases.Title(tag, cases.Compact),
+	}
+}
+
+func (r *AlertsJiraRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(constants.KindAlerts, r.renderAlerts)
+	reg.Register(constants.KindAlertsHeader, r.renderAlertsHeader)
+	reg.Register(constants.KindAlertsBody, r.renderAlertsBody)
+}
+
+// renderAlerts writes the whole callout itself, including a plain-text rendering of the body.
+func (r *AlertsJiraRenderer) renderAlerts(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	if !entering {
+		return gast.WalkContinue, nil
+	}
+	writeLines(w, r.alertLines(node, source, r.Width))
+	if node.NextSibling() != nil {
+		w.WriteString("\n")
+	}
+	return gast.WalkSkipChildren, nil
+}
+
+func (r *AlertsJiraRenderer) renderAlertsHeader(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	return gast.WalkSkipChildren, nil
+}
+
+func (r *AlertsJiraRenderer) renderAlertsBody(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	if entering {
+		writeLines(w, blockLines(node, source, r.Width, blockFormat{Alert: r.alertLines, Code: jiraCodeLines}))
+	}
+	return gast.WalkSkipChildren, nil
+}
+
+// alertLines renders an Alerts node, including any nested callouts, as a macro or panel.
+func (r *AlertsJiraRenderer) alertLines(node gast.Node, source []byte, width int) []string {
+	attrs := readAlertAttributes(node)
+	title := alertTitle(node, source)
+
+	var macro string
+	if !r.Panels {
+		macro = r.Macros[resolveKind(attrs.Kind, r.Aliases, r.GFMKinds, r.Macros)]
+	}
+
+	var params []string
+	if macro == "" {
+		macro = "panel"
+		if title == "" {
+			title = r.titleCaser.String(attrs.Kind)
+		}
+		params = append(params, "title="+escapeJira(title))
+		color := r.Colors[resolveKind(attrs.Kind, r.Aliases, r.GFMKinds, r.Colors)]
+		if color == "" {
+			color = constants.DEFAULT_COLOR
+		}
+		params = append(params, "borderColor=#"+color)
+	} else {
+		if title != "" {
+			params = append(params, "title="+escapeJira(title))
+		}
+		if r.AllowNOICON && attrs.NoIcon {
+			params = append(params, "icon=false")
+		}
+	}
+
+	open := "{" + macro
+	if len(params) > 0 {
+		open += ":" + strings.Join(params, "|")
+	}
+	lines := []string{open + "}"}
+	if body := alertBody(node); body != nil {
+		lines = append(lines, blockLines(body, source, width, blockFormat{Alert: r.alertLines, Code: jiraCodeLines})...)
+	}
+	return append(lines, "{"+macro+"}")
+}
+
+// jiraCodeLines writes a code block as a {code} macro.
+func jiraCodeLines(code []string, language string) []string {
+	open := "{code}"
+	if language != "" {
+		open = "{code:" + language + "}"
+	}
+	lines := append([]string{open}, code...)
+	return append(lines, "{code}")
+}
+
+var jiraEscapes = strings.NewReplacer(
+	`|`, `\|`,
+	`{`, `\{`,
+	`}`, `\}`,
+)
+
+// escapeJira escapes the characters that would end a macro parameter early.
+func escapeJira(s string) string {
+	return jiraEscapes.Replace(s)
+}
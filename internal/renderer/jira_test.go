@@ -0,0 +1,71 @@
+package renderer
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestAlertsJiraRenderer(t *testing.T) {
+	aliases := map[string]string{"danger": "caution"}
+
+	testCases := []struct {
+		name     string
+		config   JiraConfig
+		input    string
+		expected string
+	}{
+		{
+			name:     "Mapped kind becomes a macro",
+			config:   JiraConfig{Aliases: aliases},
+			input:    "> [!WARNING]\n> Body",
+			expected: "{note}\nBody\n{note}\n",
+		},
+		{
+			name:     "Macro with escaped title",
+			config:   JiraConfig{Aliases: aliases},
+			input:    "> [!tip] a|b {c}\n> Body",
+			expected: "{tip:title=a\\|b \\{c\\}}\nBody\n{tip}\n",
+		},
+		{
+			name:     "Alias resolves through the icon map",
+			config:   JiraConfig{Aliases: aliases, Macros: map[string]string{"caution": "warning"}},
+			input:    "> [!danger]\n> Body",
+			expected: "{warning}\nBody\n{warning}\n",
+		},
+		{
+			name:     "Unmapped kind becomes a colored panel",
+			config:   JiraConfig{Aliases: aliases, Macros: map[string]string{}, Colors: map[string]string{"bug": "CF222E"}},
+			input:    "> [!bug]\n> Body",
+			expected: "{panel:title=Bug|borderColor=#CF222E}\nBody\n{panel}\n",
+		},
+		{
+			name:     "Panels forced",
+			config:   JiraConfig{Aliases: aliases, Panels: true},
+			input:    "> [!note] Heads up\n> Body",
+			expected: "{panel:title=Heads up|borderColor=#0969DA}\nBody\n{panel}\n",
+		},
+		{
+			name:     "NOICON hides the macro icon",
+			config:   JiraConfig{Aliases: aliases, AllowNOICON: true},
+			input:    "> [!noicon-note]\n> Body",
+			expected: "{info:icon=false}\nBody\n{info}\n",
+		},
+		{
+			name:     "Code blocks and nested callouts",
+			config:   JiraConfig{Aliases: aliases},
+			input:    "> [!note]\n> ```go\n> x := 1\n> ```\n>\n> > [!tip]\n> > Inner",
+			expected: "{info}\n{code:go}\nx := 1\n{code}\n\n{tip}\nInner\n{tip}\n{info}\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newAlertsJiraRenderer(tc.config, language.English)
+			result := renderAlertsDocument(t, r, tc.input)
+			if result != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package renderer
+
+import (
+	"bytes"
+	"strings"
+
+	gast "github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/zmtcreative/gm-alert-callouts/internal/constants"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// CalloutSchemaVersion is the version of the JSON export schema. It is increased whenever a field
+// is renamed, removed or changes meaning; new optional fields do not change the version.
+const CalloutSchemaVersion = 1
+
+// Fold states reported in the foldState field of an exported callout.
+const (
+	FoldStateNone   = "none"   // The callout is not foldable
+	FoldStateOpen   = "open"   // The callout is foldable and starts expanded ('+')
+	FoldStateClosed = "closed" // The callout is foldable and starts collapsed ('-')
+)
+
+// CalloutDocument is the top-level object of the JSON export.
+type CalloutDocument struct {
+	Version  int       `json:"version"`  // Schema version (CalloutSchemaVersion)
+	Callouts []Callout `json:"callouts"` // Top-level callouts in document order
+}
+
+// Callout is the JSON representation of a single callout.
+type Callout struct {
+	Kind          string             `json:"kind"`               // Kind as written in the markdown, lower-cased and without a 'noicon-' prefix
+	CanonicalKind string             `json:"canonicalKind"`      // Primary kind when Kind is an alias, otherwise Kind
+	Title         string             `json:"title"`              // Custom title as plain text, or the default title of the kind when there is none
+	FoldState     string             `json:"foldState"`          // One of "none", "open" or "closed"
+	NoIcon        bool               `json:"noIcon"`             // Whether the callout is rendered without an icon
+	BodyHTML      string             `json:"bodyHTML"`           // Body rendered as HTML, including any nested callouts
+	BodyText      string             `json:"bodyText"`           // Body as plain text, including any nested callouts
+	SourceRange   CalloutSourceRange `json:"sourceRange"`        // Where the callout is written in the source
+	Children      []Callout          `json:"children,omitempty"` // Callouts nested in the body, in document order
+}
+
+// CalloutSourceRange locates a callout in the markdown source.
+// Start and End are byte offsets (End is exclusive and excludes the final newline);
+// StartLine and EndLine are 1-based line numbers.
+type CalloutSourceRange struct {
+	Start     int `json:"start"`
+	End       int `json:"end"`
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine"`
+}
+
+// ExportConfig holds the options for the callout exporter.
+type ExportConfig struct {
+	Aliases        map[string]string // Alias to primary kind map, used for the canonical kind
+	FoldingEnabled bool              // Whether folding functionality is enabled
+	AllowNOICON    bool              // Whether a 'noicon-' prefix suppresses the icon
+	Titles         map[string]string // Default display title per kind (the title-cased kind when missing)
+}
+
+// CalloutExporter collects the callouts of a parsed document into the JSON export structure.
+type CalloutExporter struct {
+	ExportConfig
+	titleCaser cases.Caser
+}
+
+// NewCalloutExporter returns a callout exporter for the given options.
+func NewCalloutExporter(config ExportConfig) *CalloutExporter {
+	return newCalloutExporter(config, DetectLanguageTag())
+}
+
+// newCalloutExporter is an unexported constructor that allows injecting a language tag for tests.
+func newCalloutExporter(config ExportConfig, tag language.Tag) *CalloutExporter {
+	return &CalloutExporter{
+		ExportConfig: config,
+		titleCaser:   cases.Title(tag, cases.Compact),
+	}
+}
+
+// Export collects every callout in doc. The body HTML is rendered with html, which should be the
+// HTML renderer of a goldmark instance that has the extension installed.
+func (x *CalloutExporter) Export(doc gast.Node, source []byte, html renderer.Renderer) (*CalloutDocument, error) {
+	callouts, err := x.collect(doc, source, html)
+	if err != nil {
+		return nil, err
+	}
+	if callouts == nil {
+		callouts = []Callout{}
+	}
+	return &CalloutDocument{Version: CalloutSchemaVersion, Callouts: callouts}, nil
+}
+
+// collect exports the outermost Alerts nodes below n; callouts nested in them become their children.
+func (x *CalloutExporter) collect(n gast.Node, source []byte, html renderer.Renderer) ([]Callout, error) {
+	var callouts []Callout
+	err := gast.Walk(n, func(c gast.Node, entering bool) (gast.WalkStatus, error) {
+		if !entering || c == n || c.Kind() != constants.KindAlerts {
+			return gast.WalkContinue, nil
+		}
+		callout, err := x.export(c, source, html)
+		if err != nil {
+			return gast.WalkStop, err
+		}
+		callouts = append(callouts, callout)
+		return gast.WalkSkipChildren, nil
+	})
+	return callouts, err
+}
+
+func (x *CalloutExporter) export(node gast.Node, source []byte, html renderer.Renderer) (Callout, error) {
+	attrs := readAlertAttributes(node)
+
+	callout := Callout{
+		Kind:          attrs.Kind,
+		CanonicalKind: attrs.Kind,
+		Title:         alertTitle(node, source),
+		FoldState:     FoldStateNone,
+		NoIcon:        x.AllowNOICON && attrs.NoIcon,
+		SourceRange:   sourceRange(node, source),
+	}
+	if primary, ok := x.Aliases[attrs.Kind]; ok {
+		callout.CanonicalKind = primary
+	}
+	if callout.Title == "" {
+		if title, ok := x.Titles[attrs.Kind]; ok {
+			callout.Title = title
+		} else {
+			callout.Title = x.titleCaser.String(attrs.Kind)
+		}
+	}
+	if x.FoldingEnabled && attrs.ShouldFold {
+		callout.FoldState = FoldStateOpen
+		if attrs.Closed {
+			callout.FoldState = FoldStateClosed
+		}
+	}
+
+	body := alertBody(node)
+	if body == nil {
+		return callout, nil
+	}
+
+	var buf bytes.Buffer
+	for c := body.FirstChild(); c != nil; c = c.NextSibling() {
+		if err := html.Render(&buf, source, c); err != nil {
+			return callout, err
+		}
+	}
+	callout.BodyHTML = buf.String()
+	callout.BodyText = strings.Join(blockLines(body, source, 0, blockFormat{Alert: x.textLines}), "\n")
+
+	children, err := x.collect(body, source, html)
+	callout.Children = children
+	return callout, err
+}
+
+// textLines writes a nested callout in the body text as a label line followed by its indented body.
+func (x *CalloutExporter) textLines(node gast.Node, source []byte, width int) []string {
+	attrs := readAlertAttributes(node)
+	label := strings.ToUpper(attrs.Kind)
+	if title := alertTitle(node, source); title != "" {
+		label += ": " + title
+	}
+	lines := []string{label}
+	if body := alertBody(node); body != nil {
+		lines = append(lines, prefixLines(blockLines(body, source, 0, blockFormat{Alert: x.textLines}), "  ", "  ")...)
+	}
+	return lines
+}
+
+// sourceRange reads the start and stop offsets the parser records on an Alerts node.
+func sourceRange(node gast.Node, source []byte) CalloutSourceRange {
+	var start, stop int
+	if v, ok := node.AttributeString("start"); ok {
+		start, _ = v.(int)
+	}
+	if v, ok := node.AttributeString("stop"); ok {
+		stop, _ = v.(int)
+	}
+	start = min(max(start, 0), len(source))
+	stop = min(max(stop, start), len(source))
+	for stop > start && (source[stop-1] == '\n' || source[stop-1] == '\r') {
+		stop--
+	}
+	return CalloutSourceRange{
+		Start:     start,
+		End:       stop,
+		StartLine: bytes.Count(source[:start], []byte("\n")) + 1,
+		EndLine:   bytes.Count(source[:stop], []byte("\n")) + 1,
+	}
+}
@@ -0,0 +1,158 @@
+package renderer
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/util"
+	"golang.org/x/text/language"
+)
+
+// newExportHTMLRenderer returns an HTML renderer with the alert renderers registered.
+func newExportHTMLRenderer(icons map[string]string) renderer.Renderer {
+	return renderer.NewRenderer(renderer.WithNodeRenderers(
+		util.Prioritized(html.NewRenderer(), 1000),
+		util.Prioritized(NewAlertsHTMLRenderer(icons, true, 0, true, true), 0),
+		util.Prioritized(NewAlertsHeaderHTMLRenderer(icons, true, 0, true, true), 0),
+		util.Prioritized(NewAlertsBodyHTMLRenderer(), 0),
+	))
+}
+
+func exportCallouts(t *testing.T, config ExportConfig, source string) *CalloutDocument {
+	t.Helper()
+	doc := parseAlertsDocument(t, source)
+	x := newCalloutExporter(config, language.English)
+	result, err := x.Export(doc, []byte(source), newExportHTMLRenderer(map[string]string{"note": "<svg>note</svg>"}))
+	if err != nil {
+		t.Fatalf("Export returned error: %v", err)
+	}
+	return result
+}
+
+func TestCalloutExporterFields(t *testing.T) {
+	source := "Intro\n\n> [!Info]- Read *this*\n> Body text\n> with **markup**.\n\nOutro\n"
+	result := exportCallouts(t, ExportConfig{Aliases: map[string]string{"info": "note"}, FoldingEnabled: true}, source)
+
+	if result.Version != CalloutSchemaVersion {
+		t.Errorf("Expected version %d, got %d", CalloutSchemaVersion, result.Version)
+	}
+	if len(result.Callouts) != 1 {
+		t.Fatalf("Expected 1 callout, got %d", len(result.Callouts))
+	}
+
+	c := result.Callouts[0]
+	if c.Kind != "info" || c.CanonicalKind != "note" {
+		t.Errorf("Expected kind 'info' with canonical kind 'note', got %q and %q", c.Kind, c.CanonicalKind)
+	}
+	if c.Title != "Read this" {
+		t.Errorf("Expected title 'Read this', got %q", c.Title)
+	}
+	if c.FoldState != FoldStateClosed {
+		t.Errorf("Expected fold state 'closed', got %q", c.FoldState)
+	}
+	if c.BodyHTML != "<p>Body text\nwith <strong>markup</strong>.</p>\n" {
+		t.Errorf("Unexpected body HTML: %q", c.BodyHTML)
+	}
+	if c.BodyText != "Body text with markup." {
+		t.Errorf("Unexpected body text: %q", c.BodyText)
+	}
+
+	expectedRange := CalloutSourceRange{Start: 7, End: 7 + len("> [!Info]- Read *this*\n> Body text\n> with **markup**."), StartLine: 3, EndLine: 5}
+	if c.SourceRange != expectedRange {
+		t.Errorf("Expected source range %+v, got %+v", expectedRange, c.SourceRange)
+	}
+	if got := source[c.SourceRange.Start:c.SourceRange.End]; !strings.HasPrefix(got, "> [!Info]") || !strings.HasSuffix(got, "**markup**.") {
+		t.Errorf("Source range does not cover the callout: %q", got)
+	}
+}
+
+func TestCalloutExporterDefaults(t *testing.T) {
+	source := "> [!noicon-tip]+\n> Body\n"
+
+	testCases := []struct {
+		name   string
+		config ExportConfig
+		fold   string
+		noIcon bool
+	}{
+		{"folding and NOICON enabled", ExportConfig{FoldingEnabled: true, AllowNOICON: true}, FoldStateOpen, true},
+		{"folding and NOICON disabled", ExportConfig{}, FoldStateNone, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := exportCallouts(t, tc.config, source).Callouts[0]
+			if c.Kind != "tip" || c.CanonicalKind != "tip" {
+				t.Errorf("Expected kind and canonical kind 'tip', got %q and %q", c.Kind, c.CanonicalKind)
+			}
+			if c.Title != "Tip" {
+				t.Errorf("Expected the title-cased kind as title, got %q", c.Title)
+			}
+			if c.FoldState != tc.fold {
+				t.Errorf("Expected fold state %q, got %q", tc.fold, c.FoldState)
+			}
+			if c.NoIcon != tc.noIcon {
+				t.Errorf("Expected noIcon %v, got %v", tc.noIcon, c.NoIcon)
+			}
+		})
+	}
+}
+
+func TestCalloutExporterNested(t *testing.T) {
+	source := "> [!note]\n> Outer\n>\n> > [!warning] Inner\n> > Careful\n\n> [!tip]\n> Second\n"
+	result := exportCallouts(t, ExportConfig{}, source)
+
+	if len(result.Callouts) != 2 {
+		t.Fatalf("Expected 2 top-level callouts, got %d", len(result.Callouts))
+	}
+
+	outer := result.Callouts[0]
+	if len(outer.Children) != 1 {
+		t.Fatalf("Expected 1 nested callout, got %d", len(outer.Children))
+	}
+	inner := outer.Children[0]
+	if inner.Kind != "warning" || inner.Title != "Inner" || inner.BodyText != "Careful" {
+		t.Errorf("Unexpected nested callout: %+v", inner)
+	}
+	if inner.SourceRange.StartLine != 4 || inner.SourceRange.EndLine != 5 {
+		t.Errorf("Expected nested callout on lines 4-5, got %+v", inner.SourceRange)
+	}
+	if outer.SourceRange.StartLine != 1 || outer.SourceRange.EndLine != 5 {
+		t.Errorf("Expected outer callout on lines 1-5, got %+v", outer.SourceRange)
+	}
+	if outer.BodyText != "Outer\n\nWARNING: Inner\n  Careful" {
+		t.Errorf("Unexpected outer body text: %q", outer.BodyText)
+	}
+	if !strings.Contains(outer.BodyHTML, `data-callout="warning"`) {
+		t.Errorf("Expected the nested callout in the outer body HTML, got %q", outer.BodyHTML)
+	}
+	if len(result.Callouts[1].Children) != 0 {
+		t.Errorf("Expected no children for the second callout")
+	}
+}
+
+func TestCalloutExporterJSON(t *testing.T) {
+	result := exportCallouts(t, ExportConfig{}, "No callouts here.\n")
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	if string(data) != `{"version":1,"callouts":[]}` {
+		t.Errorf("Unexpected JSON for a document without callouts: %s", data)
+	}
+
+	result = exportCallouts(t, ExportConfig{}, "> [!note]\n")
+	data, _ = json.Marshal(result.Callouts[0])
+	for _, key := range []string{`"kind":"note"`, `"canonicalKind":"note"`, `"title":"Note"`, `"foldState":"none"`, `"noIcon":false`, `"bodyHTML":""`, `"bodyText":""`, `"sourceRange":{"start":0,"end":9,"startLine":1,"endLine":1}`} {
+		if !strings.Contains(string(data), key) {
+			t.Errorf("Expected %s in %s", key, data)
+		}
+	}
+	if strings.Contains(string(data), "children") {
+		t.Errorf("Expected children to be omitted when empty: %s", data)
+	}
+}
@@ -0,0 +1,17 @@
+package renderer
+
+import (
+	"slices"
+)
+
+// ParentKinds returns the chain of kinds that kind extends according to extends (kind to parent),
+// nearest first. A cycle ends the chain at the first repeated kind.
+func ParentKinds(kind string, extends map[string]string) []string {
+	var parents []string
+	seen := []string{kind}
+	for parent := extends[kind]; parent != "" && !slices.Contains(seen, parent); parent = extends[parent] {
+		seen = append(seen, parent)
+		parents = append(parents, parent)
+	}
+	return parents
+}
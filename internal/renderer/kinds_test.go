@@ -0,0 +1,20 @@
+package renderer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParentKinds(t *testing.T) {
+	extends := map[string]string{"security": "warning", "warning": "caution", "loop-a": "loop-b", "loop-b": "loop-a"}
+
+	if got := strings.Join(ParentKinds("security", extends), ","); got != "warning,caution" {
+		t.Errorf("Expected warning,caution, got %q", got)
+	}
+	if got := ParentKinds("note", extends); len(got) != 0 {
+		t.Errorf("Expected no parents for note, got %v", got)
+	}
+	if got := strings.Join(ParentKinds("loop-a", extends), ","); got != "loop-b" {
+		t.Errorf("Expected the cycle to stop at loop-b, got %q", got)
+	}
+}
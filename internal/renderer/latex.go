@@ -0,0 +1,167 @@
+package renderer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zmtcreative/gm-alert-callouts/internal/constants"
+	gast "github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// LaTeXIconMode selects how icons are represented in LaTeX output.
+type LaTeXIconMode int
+
+const (
+	// LaTeXIconsSymbol replaces the SVG icons with commands from a symbol font package.
+	LaTeXIconsSymbol LaTeXIconMode = iota
+	// LaTeXIconsNone omits icons from the callout titles.
+	LaTeXIconsNone
+)
+
+// LaTeXConfig holds the options for the LaTeX renderer and preamble.
+type LaTeXConfig struct {
+	Icons         map[string]string // Icon map; every key gets its own environment in the preamble
+	Aliases       map[string]string // Alias to primary kind map, so aliases take the color and symbol of their primary
+	GFMKinds      map[string]string // GFM kind per kind, whose color and symbol a kind takes when it has none
+	AllowNOICON   bool              // Whether a 'noicon-' prefix suppresses the symbol
+	Colors        map[string]string // Hex accent color per kind (constants.KIND_COLOR when nil)
+	IconMode      LaTeXIconMode     // How icons are represented
+	Symbols       map[string]string // Symbol command per kind (constants.KIND_LATEX_SYMBOL when nil)
+	SymbolPackage string            // Package providing the symbol commands
+}
+
+// AlertsLaTeXRenderer renders the alert nodes as tcolorbox environments for a goldmark-to-LaTeX renderer.
+// Each kind in the icon set has an environment named 'callout-<kind>' defined by LaTeXPreamble;
+// kinds outside the icon set use the generic 'callout' environment.
+// The body is left to the renderers of the core nodes.
+type AlertsLaTeXRenderer struct {
+	LaTeXConfig
+	titleCaser cases.Caser
+}
+
+// NewAlertsLaTeXRenderer returns a LaTeX renderer for the Alerts, AlertsHeader and AlertsBody nodes.
+func NewAlertsLaTeXRenderer(config LaTeXConfig) renderer.NodeRenderer {
+	return newAlertsLaTeXRenderer(config, DetectLanguageTag())
+}
+
+// newAlertsLaTeXRenderer is an unexported constructor that allows injecting a language tag for tests.
+func newAlertsLaTeXRenderer(config LaTeXConfig, tag language.Tag) *AlertsLaTeXRenderer {
+	return &AlertsLaTeXRenderer{
+		LaTeXConfig: config,
+		titleCaser:  cases.Title(tag, cases.Compact),
+	}
+}
+
+func (r *AlertsLaTeXRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(constants.KindAlerts, r.renderAlerts)
+	reg.Register(constants.KindAlertsHeader, r.renderAlertsHeader)
+	reg.Register(constants.KindAlertsBody, r.renderAlertsBody)
+}
+
+func (r *AlertsLaTeXRenderer) renderAlerts(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	attrs := readAlertAttributes(node)
+	env := r.environment(attrs.Kind)
+
+	if !entering {
+		w.WriteString(`\end{` + env + "}\n")
+		return gast.WalkContinue, nil
+	}
+
+	title := alertTitle(node, source)
+	if env == "callout" && title == "" {
+		title = r.titleCaser.String(attrs.Kind)
+	}
+
+	w.WriteString(`\begin{` + env + `}`)
+	if r.AllowNOICON && attrs.NoIcon {
+		// An empty optional argument replaces the default symbol of the environment
+		w.WriteString(`[]`)
+	}
+	w.WriteString(`{` + EscapeLaTeX(title) + "}\n")
+	return gast.WalkContinue, nil
+}
+
+// renderAlertsHeader skips the header, since the title is passed as the environment argument.
+func (r *AlertsLaTeXRenderer) renderAlertsHeader(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	return gast.WalkSkipChildren, nil
+}
+
+func (r *AlertsLaTeXRenderer) renderAlertsBody(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	return gast.WalkContinue, nil
+}
+
+// environment returns the environment name used for kind.
+func (r *AlertsLaTeXRenderer) environment(kind string) string {
+	if _, ok := r.Icons[kind]; ok {
+		return "callout-" + kind
+	}
+	return "callout"
+}
+
+// LaTeXPreamble returns the preamble lines that define the colors and tcolorbox environments used
+// by the LaTeX renderer: one environment per key of the icon map plus the generic 'callout'.
+// Aliases take the color and symbol of their primary kind.
+func LaTeXPreamble(config LaTeXConfig) string {
+	return latexPreamble(config, DetectLanguageTag())
+}
+
+func latexPreamble(config LaTeXConfig, tag language.Tag) string {
+	titleCaser := cases.Title(tag, cases.Compact)
+	colors := config.Colors
+	if colors == nil {
+		colors = constants.KIND_COLOR
+	}
+	symbols := config.Symbols
+	if symbols == nil {
+		symbols = constants.KIND_LATEX_SYMBOL
+	}
+
+	var sb strings.Builder
+	sb.WriteString("% Callout environments generated by gm-alert-callouts\n")
+	sb.WriteString("\\usepackage[most]{tcolorbox}\n")
+	if config.IconMode == LaTeXIconsSymbol && config.SymbolPackage != "" {
+		sb.WriteString(`\usepackage{` + config.SymbolPackage + "}\n")
+	}
+	sb.WriteString("\\tcbset{callout/.style={enhanced, breakable, boxrule=0.5pt, left=4pt, right=4pt, fonttitle=\\bfseries}}\n")
+	sb.WriteString("\\newcommand{\\calloutheading}[3]{\\ifx\\relax#1\\relax\\else#1~\\fi\\ifx\\relax#3\\relax#2\\else#3\\fi}\n")
+	fmt.Fprintf(&sb, "\\definecolor{callout}{HTML}{%s}\n", constants.DEFAULT_COLOR)
+	sb.WriteString("\\newtcolorbox{callout}[2][]{callout, colframe=callout, colback=callout!5!white, coltitle=white, title={\\calloutheading{#1}{}{#2}}}\n")
+
+	for _, kind := range sortedKeys(config.Icons) {
+		color := colors[resolveKind(kind, config.Aliases, config.GFMKinds, colors)]
+		if color == "" {
+			color = constants.DEFAULT_COLOR
+		}
+		symbol := ""
+		if config.IconMode == LaTeXIconsSymbol {
+			symbol = symbols[resolveKind(kind, config.Aliases, config.GFMKinds, symbols)]
+		}
+		name := "callout-" + kind
+		fmt.Fprintf(&sb, "\\definecolor{%s}{HTML}{%s}\n", name, strings.ToUpper(color))
+		fmt.Fprintf(&sb, "\\newtcolorbox{%s}[2][%s]{callout, colframe=%s, colback=%s!5!white, coltitle=white, title={\\calloutheading{#1}{%s}{#2}}}\n",
+			name, symbol, name, name, EscapeLaTeX(titleCaser.String(kind)))
+	}
+	return sb.String()
+}
+
+var latexEscapes = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`$`, `\$`,
+	`&`, `\&`,
+	`#`, `\#`,
+	`^`, `\textasciicircum{}`,
+	`_`, `\_`,
+	`%`, `\%`,
+	`~`, `\textasciitilde{}`,
+)
+
+// EscapeLaTeX escapes the characters that have a special meaning in LaTeX text.
+func EscapeLaTeX(s string) string {
+	return latexEscapes.Replace(s)
+}
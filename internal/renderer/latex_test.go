@@ -0,0 +1,113 @@
+package renderer
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestAlertsLaTeXRenderer(t *testing.T) {
+	icons := map[string]string{"note": "<svg>n</svg>", "info": "<svg>n</svg>"}
+
+	testCases := []struct {
+		name     string
+		config   LaTeXConfig
+		input    string
+		expected string
+	}{
+		{
+			name:     "Known kind without title",
+			config:   LaTeXConfig{Icons: icons},
+			input:    "> [!note]\n> Body",
+			expected: "\\begin{callout-note}{}\n\\end{callout-note}\n",
+		},
+		{
+			name:     "Alias keeps its own environment",
+			config:   LaTeXConfig{Icons: icons},
+			input:    "> [!INFO] Read this\n> Body",
+			expected: "\\begin{callout-info}{Read this}\n\\end{callout-info}\n",
+		},
+		{
+			name:     "Unknown kind uses generic environment with title-cased kind",
+			config:   LaTeXConfig{Icons: icons},
+			input:    "> [!custom]\n> Body",
+			expected: "\\begin{callout}{Custom}\n\\end{callout}\n",
+		},
+		{
+			name:     "Title is escaped",
+			config:   LaTeXConfig{Icons: icons},
+			input:    "> [!note] 100% of $5 & #1_a\n> Body",
+			expected: "\\begin{callout-note}{100\\% of \\$5 \\& \\#1\\_a}\n\\end{callout-note}\n",
+		},
+		{
+			name:     "NOICON passes an empty symbol",
+			config:   LaTeXConfig{Icons: icons, AllowNOICON: true},
+			input:    "> [!noicon-note]\n> Body",
+			expected: "\\begin{callout-note}[]{}\n\\end{callout-note}\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newAlertsLaTeXRenderer(tc.config, language.English)
+			result := renderAlertsDocument(t, r, tc.input)
+			if result != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestLaTeXPreamble(t *testing.T) {
+	icons := map[string]string{"note": "<svg>n</svg>", "info": "<svg>n</svg>", "my_kind": "<svg>m</svg>"}
+	aliases := map[string]string{"info": "note"}
+
+	t.Run("Symbols and colors", func(t *testing.T) {
+		result := latexPreamble(LaTeXConfig{Icons: icons, Aliases: aliases, SymbolPackage: "fontawesome5", Colors: map[string]string{"note": "0969da"}}, language.English)
+
+		expected := []string{
+			"\\usepackage[most]{tcolorbox}\n",
+			"\\usepackage{fontawesome5}\n",
+			"\\newtcolorbox{callout}[2][]{",
+			"\\definecolor{callout-note}{HTML}{0969DA}\n",
+			"\\definecolor{callout-info}{HTML}{0969DA}\n",
+			"\\newtcolorbox{callout-info}[2][\\faInfoCircle]{callout, colframe=callout-info, colback=callout-info!5!white, coltitle=white, title={\\calloutheading{#1}{Info}{#2}}}\n",
+			"\\definecolor{callout-my_kind}{HTML}{6E7781}\n",
+			"title={\\calloutheading{#1}{My\\_kind}{#2}}",
+		}
+		for _, e := range expected {
+			if !strings.Contains(result, e) {
+				t.Errorf("Expected preamble to contain %q, got:\n%s", e, result)
+			}
+		}
+	})
+
+	t.Run("Icons omitted", func(t *testing.T) {
+		result := latexPreamble(LaTeXConfig{Icons: icons, Aliases: aliases, IconMode: LaTeXIconsNone, SymbolPackage: "fontawesome5"}, language.English)
+
+		if strings.Contains(result, "fontawesome5") || strings.Contains(result, "\\fa") {
+			t.Errorf("Expected no symbol font, got:\n%s", result)
+		}
+		if !strings.Contains(result, "\\newtcolorbox{callout-note}[2][]{") {
+			t.Errorf("Expected empty default symbol, got:\n%s", result)
+		}
+	})
+
+	t.Run("Deterministic output", func(t *testing.T) {
+		first := latexPreamble(LaTeXConfig{Icons: icons}, language.English)
+		for i := 0; i < 5; i++ {
+			if latexPreamble(LaTeXConfig{Icons: icons}, language.English) != first {
+				t.Fatal("Expected identical preamble on every call")
+			}
+		}
+	})
+}
+
+func TestEscapeLaTeX(t *testing.T) {
+	result := EscapeLaTeX(`a\b{c}~^`)
+	expected := `a\textbackslash{}b\{c\}\textasciitilde{}\textasciicircum{}`
+	if result != expected {
+		t.Errorf("Expected %q, got %q", expected, result)
+	}
+}
@@ -0,0 +1,338 @@
+package renderer
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/zmtcreative/gm-alert-callouts/internal/constants"
+	gast "github.com/yuin/goldmark/ast"
+	"golang.org/x/text/width"
+)
+
+// alertAttributes holds the values the parser stores as attributes on an Alerts or AlertsHeader node.
+type alertAttributes struct {
+	Kind       string
+	Closed     bool
+	ShouldFold bool
+	NoIcon     bool
+}
+
+// readAlertAttributes collects the parser attributes from an Alerts or AlertsHeader node.
+// The 'kind' attribute is stored as []uint8 on Alerts and as a string on AlertsHeader, so both are handled.
+func readAlertAttributes(node gast.Node) alertAttributes {
+	var attrs alertAttributes
+	if t, ok := node.AttributeString("kind"); ok {
+		if typeBytes, isBytes := t.([]uint8); isBytes {
+			attrs.Kind = strings.ToLower(string(typeBytes))
+		} else if typeStr, isStr := t.(string); isStr {
+			attrs.Kind = strings.ToLower(typeStr)
+		}
+	}
+	if t, ok := node.AttributeString("closed"); ok {
+		attrs.Closed, _ = t.(bool)
+	}
+	if t, ok := node.AttributeString("shouldfold"); ok {
+		attrs.ShouldFold, _ = t.(bool)
+	}
+	if t, ok := node.AttributeString("noicon"); ok {
+		attrs.NoIcon, _ = t.(bool)
+	}
+	return attrs
+}
+
+// alertHeader returns the AlertsHeader child of an Alerts node, or nil if there is none.
+func alertHeader(node gast.Node) gast.Node {
+	for c := node.FirstChild(); c != nil; c = c.NextSibling() {
+		if c.Kind() == constants.KindAlertsHeader {
+			return c
+		}
+	}
+	return nil
+}
+
+// alertBody returns the AlertsBody child of an Alerts node, or nil if there is none.
+func alertBody(node gast.Node) gast.Node {
+	for c := node.FirstChild(); c != nil; c = c.NextSibling() {
+		if c.Kind() == constants.KindAlertsBody {
+			return c
+		}
+	}
+	return nil
+}
+
+// alertTitle returns the custom title of an Alerts or AlertsHeader node as plain text.
+// An empty string means the markdown did not supply a title.
+func alertTitle(node gast.Node, source []byte) string {
+	header := node
+	if node.Kind() == constants.KindAlerts {
+		header = alertHeader(node)
+	}
+	if header == nil {
+		return ""
+	}
+	return strings.TrimSpace(inlineText(header, source))
+}
+
+// inlineText flattens the inline content of n into plain text.
+// Markup is dropped, soft line breaks become spaces and hard line breaks become newlines.
+func inlineText(n gast.Node, source []byte) string {
+	var sb strings.Builder
+	writeInlineText(&sb, n, source)
+	return sb.String()
+}
+
+func writeInlineText(sb *strings.Builder, n gast.Node, source []byte) {
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		switch v := c.(type) {
+		case *gast.Text:
+			sb.Write(v.Segment.Value(source))
+			if v.HardLineBreak() {
+				sb.WriteString("\n")
+			} else if v.SoftLineBreak() {
+				sb.WriteString(" ")
+			}
+		case *gast.String:
+			sb.Write(v.Value)
+		case *gast.AutoLink:
+			sb.Write(v.URL(source))
+		case *gast.RawHTML:
+			// raw HTML has no plain text representation
+		case *gast.TextBlock, *gast.Paragraph:
+			writeInlineText(sb, c, source)
+			sb.WriteString(" ")
+		default:
+			writeInlineText(sb, c, source)
+		}
+	}
+}
+
+// rawLines returns the source lines of a code or HTML block without their trailing newlines.
+func rawLines(n gast.Node, source []byte) []string {
+	lines := n.Lines()
+	result := make([]string, 0, lines.Len())
+	for i := 0; i < lines.Len(); i++ {
+		seg := lines.At(i)
+		result = append(result, strings.TrimRight(string(seg.Value(source)), "\r\n"))
+	}
+	return result
+}
+
+// blockFormat lets each output format draw the blocks that have a format-specific representation.
+type blockFormat struct {
+	// Alert renders a nested Alerts node into lines that fit within width.
+	Alert func(node gast.Node, source []byte, width int) []string
+	// Code renders the lines of a code block. The lines are written verbatim when Code is nil.
+	Code func(lines []string, language string) []string
+}
+
+// blockLines renders the block children of n as plain text lines.
+// Paragraphs are wrapped to width (0 disables wrapping), blocks are separated by a blank line,
+// and nested Alerts nodes and code blocks are handed to format so each output format can draw them its own way.
+func blockLines(n gast.Node, source []byte, width int, format blockFormat) []string {
+	var result []string
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		lines := blockNodeLines(c, source, width, format)
+		if len(lines) == 0 {
+			continue
+		}
+		if len(result) > 0 {
+			result = append(result, "")
+		}
+		result = append(result, lines...)
+	}
+	return result
+}
+
+func blockNodeLines(c gast.Node, source []byte, width int, format blockFormat) []string {
+	switch v := c.(type) {
+	case *gast.Paragraph, *gast.TextBlock, *gast.Heading:
+		return wrapText(strings.TrimSpace(inlineText(v, source)), width)
+	case *gast.FencedCodeBlock:
+		if format.Code != nil {
+			return format.Code(rawLines(v, source), string(v.Language(source)))
+		}
+		return rawLines(v, source)
+	case *gast.CodeBlock:
+		if format.Code != nil {
+			return format.Code(rawLines(v, source), "")
+		}
+		return rawLines(v, source)
+	case *gast.HTMLBlock:
+		return rawLines(v, source)
+	case *gast.ThematicBreak:
+		return []string{"---"}
+	case *gast.Blockquote:
+		return prefixLines(blockLines(v, source, width-2, format), "> ", "> ")
+	case *gast.List:
+		return listLines(v, source, width, format)
+	}
+	if c.Kind() == constants.KindAlerts && format.Alert != nil {
+		return format.Alert(c, source, width)
+	}
+	return blockLines(c, source, width, format)
+}
+
+func listLines(list *gast.List, source []byte, width int, format blockFormat) []string {
+	var result []string
+	number := list.Start
+	for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+		marker := "- "
+		if list.IsOrdered() {
+			marker = strconv.Itoa(number) + ". "
+			number++
+		}
+		pad := strings.Repeat(" ", utf8.RuneCountInString(marker))
+		itemLines := blockLines(item, source, width-len(pad), format)
+		if list.IsTight {
+			itemLines = dropBlankLines(itemLines)
+		} else if len(result) > 0 {
+			result = append(result, "")
+		}
+		result = append(result, prefixLines(itemLines, marker, pad)...)
+	}
+	return result
+}
+
+func dropBlankLines(lines []string) []string {
+	result := lines[:0]
+	for _, line := range lines {
+		if line != "" {
+			result = append(result, line)
+		}
+	}
+	return result
+}
+
+// prefixLines prefixes the first line with first and every following line with rest.
+// Blank lines are only given the trimmed prefix so no trailing whitespace is produced.
+func prefixLines(lines []string, first, rest string) []string {
+	result := make([]string, len(lines))
+	for i, line := range lines {
+		prefix := rest
+		if i == 0 {
+			prefix = first
+		}
+		if line == "" {
+			result[i] = strings.TrimRight(prefix, " ")
+		} else {
+			result[i] = prefix + line
+		}
+	}
+	return result
+}
+
+// wrapText word-wraps text so no line is wider than width columns (unless a single word is wider).
+// A width of 0 or less disables wrapping. Existing newlines are kept as line breaks.
+func wrapText(text string, width int) []string {
+	var result []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			result = append(result, "")
+			continue
+		}
+		if width <= 0 {
+			result = append(result, strings.Join(words, " "))
+			continue
+		}
+		line := words[0]
+		for _, word := range words[1:] {
+			if displayWidth(line)+1+displayWidth(word) > width {
+				result = append(result, line)
+				line = word
+			} else {
+				line += " " + word
+			}
+		}
+		result = append(result, line)
+	}
+	return result
+}
+
+// breakLine splits line into pieces of at most width columns, breaking anywhere, for lines that
+// must fit a fixed width even when a single word or code line is longer. ANSI escape sequences
+// are kept whole and take no columns.
+func breakLine(line string, width int) []string {
+	if width <= 0 || displayWidth(line) <= width {
+		return []string{line}
+	}
+	var result []string
+	var sb strings.Builder
+	used := 0
+	inEscape := false
+	for _, r := range line {
+		w := 0
+		switch {
+		case inEscape:
+			if r >= '@' && r <= '~' && r != '[' {
+				inEscape = false
+			}
+		case r == '\x1b':
+			inEscape = true
+		default:
+			w = displayWidth(string(r))
+		}
+		if used > 0 && used+w > width {
+			result = append(result, sb.String())
+			sb.Reset()
+			used = 0
+		}
+		sb.WriteRune(r)
+		used += w
+	}
+	return append(result, sb.String())
+}
+
+// displayWidth returns the number of terminal columns s occupies.
+// East Asian wide and fullwidth characters (which include most emoji) take two columns,
+// combining marks, variation selectors, other format characters and ANSI escape sequences take none.
+func displayWidth(s string) int {
+	n := 0
+	inEscape := false
+	for _, r := range s {
+		switch {
+		case inEscape:
+			// CSI sequences end with a byte in the range '@' to '~' (the '[' introducer is skipped here)
+			if r >= '@' && r <= '~' && r != '[' {
+				inEscape = false
+			}
+		case r == '\x1b':
+			inEscape = true
+		case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		case width.LookupRune(r).Kind() == width.EastAsianWide, width.LookupRune(r).Kind() == width.EastAsianFullwidth:
+			n += 2
+		default:
+			n++
+		}
+	}
+	return n
+}
+
+// resolveKind maps kind onto a key of known: kind itself when it is a key, otherwise the primary
+// kind of an alias according to aliases (alias to primary kind), and finally the GFM kind that
+// gfmKinds names for it, so outputs that only map the five GFM kinds still place custom kinds.
+// An empty string is returned when no match is found.
+func resolveKind[V any](kind string, aliases map[string]string, gfmKinds map[string]string, known map[string]V) string {
+	if _, ok := known[kind]; ok {
+		return kind
+	}
+	for _, mapped := range []string{aliases[kind], gfmKinds[kind]} {
+		if _, ok := known[mapped]; ok && mapped != "" {
+			return mapped
+		}
+	}
+	return ""
+}
+
+// sortedKeys returns the keys of m in sorted order so output built from maps is deterministic.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
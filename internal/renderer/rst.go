@@ -0,0 +1,142 @@
+package renderer
+
+import (
+	"strings"
+
+	"github.com/zmtcreative/gm-alert-callouts/internal/constants"
+	gast "github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+// RSTDirectives maps the kinds that have a reStructuredText admonition directive of the same name.
+var RSTDirectives = map[string]string{
+	"attention": "attention",
+	"caution":   "caution",
+	"danger":    "danger",
+	"error":     "error",
+	"hint":      "hint",
+	"important": "important",
+	"note":      "note",
+	"tip":       "tip",
+	"warning":   "warning",
+}
+
+// RSTConfig holds the options for the reStructuredText renderer.
+type RSTConfig struct {
+	Aliases        map[string]string // Alias to primary kind map, used to resolve aliases to a mapped kind
+	GFMKinds       map[string]string // GFM kind per kind, used when the map names neither the kind nor its primary
+	FoldingEnabled bool              // Whether folding functionality is enabled
+	Directives     map[string]string // Directive per kind (RSTDirectives when nil)
+	Fallback       string            // Directive for kinds without a mapping
+	Collapsible    bool              // Whether folded callouts get Sphinx's ':collapsible:' option
+	Indent         int               // Number of spaces the directive body is indented by
+	Width          int               // Column to wrap paragraphs at (0 disables wrapping)
+}
+
+// AlertsRSTRenderer renders the alert nodes as reStructuredText admonition directives
+// (`.. note:: Title`) with an indented body.
+// Kinds are resolved through the icon map so aliases use the directive of their primary kind.
+// When the directive differs from the kind, the kind is kept in a ':class:' option.
+type AlertsRSTRenderer struct {
+	RSTConfig
+}
+
+// NewAlertsRSTRenderer returns a reStructuredText renderer for the Alerts, AlertsHeader and AlertsBody nodes.
+func NewAlertsRSTRenderer(config RSTConfig) renderer.NodeRenderer {
+	if config.Directives == nil {
+		config.Directives = RSTDirectives
+	}
+	if config.Fallback == "" {
+		config.Fallback = "note"
+	}
+	if config.Indent <= 0 {
+		config.Indent = 3
+	}
+	return &AlertsRSTRenderer{RSTConfig: config}
+}
+
+func (r *AlertsRSTRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(constants.KindAlerts, r.renderAlerts)
+	reg.Register(constants.KindAlertsHeader, r.renderAlertsHeader)
+	reg.Register(constants.KindAlertsBody, r.renderAlertsBody)
+}
+
+// renderAlerts writes the whole directive itself, since reStructuredText requires the body to be indented.
+func (r *AlertsRSTRenderer) renderAlerts(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	if !entering {
+		return gast.WalkContinue, nil
+	}
+	writeLines(w, r.directiveLines(node, source, r.Width))
+	if node.NextSibling() != nil {
+		w.WriteString("\n")
+	}
+	return gast.WalkSkipChildren, nil
+}
+
+func (r *AlertsRSTRenderer) renderAlertsHeader(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	return gast.WalkSkipChildren, nil
+}
+
+func (r *AlertsRSTRenderer) renderAlertsBody(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	if entering {
+		writeLines(w, r.bodyLines(node, source, r.Width))
+	}
+	return gast.WalkSkipChildren, nil
+}
+
+// directiveLines renders an Alerts node, including any nested callouts, as a directive.
+func (r *AlertsRSTRenderer) directiveLines(node gast.Node, source []byte, width int) []string {
+	attrs := readAlertAttributes(node)
+	directive := r.directive(attrs.Kind)
+	pad := strings.Repeat(" ", r.Indent)
+
+	first := ".. " + directive + "::"
+	if title := alertTitle(node, source); title != "" {
+		first += " " + title
+	}
+	lines := []string{first}
+	if directive != attrs.Kind {
+		lines = append(lines, pad+":class: "+attrs.Kind)
+	}
+	if r.Collapsible && r.FoldingEnabled && attrs.ShouldFold {
+		state := "open"
+		if attrs.Closed {
+			state = "closed"
+		}
+		lines = append(lines, pad+":collapsible: "+state)
+	}
+
+	if body := alertBody(node); body != nil {
+		if bodyLines := r.bodyLines(body, source, width); len(bodyLines) > 0 {
+			lines = append(lines, "")
+			lines = append(lines, bodyLines...)
+		}
+	}
+	return lines
+}
+
+func (r *AlertsRSTRenderer) bodyLines(body gast.Node, source []byte, width int) []string {
+	pad := strings.Repeat(" ", r.Indent)
+	format := blockFormat{Alert: r.directiveLines, Code: r.codeLines}
+	return prefixLines(blockLines(body, source, width-r.Indent, format), pad, pad)
+}
+
+// codeLines writes a code block as a 'code-block' directive, or as a literal block when it has no language.
+func (r *AlertsRSTRenderer) codeLines(code []string, language string) []string {
+	lines := []string{"::"}
+	if language != "" {
+		lines[0] = ".. code-block:: " + language
+	}
+	lines = append(lines, "")
+	pad := strings.Repeat(" ", r.Indent)
+	return append(lines, prefixLines(code, pad, pad)...)
+}
+
+// directive returns the admonition directive for kind, falling back to the configured directive.
+func (r *AlertsRSTRenderer) directive(kind string) string {
+	if directive := r.Directives[resolveKind(kind, r.Aliases, r.GFMKinds, r.Directives)]; directive != "" {
+		return directive
+	}
+	return r.Fallback
+}
@@ -0,0 +1,75 @@
+package renderer
+
+import (
+	"testing"
+)
+
+func TestAlertsRSTRenderer(t *testing.T) {
+	aliases := map[string]string{"tips": "tip"}
+
+	testCases := []struct {
+		name     string
+		config   RSTConfig
+		input    string
+		expected string
+	}{
+		{
+			name:     "Directive with indented body",
+			config:   RSTConfig{Aliases: aliases},
+			input:    "> [!NOTE]\n> Body text\n>\n> More",
+			expected: ".. note::\n\n   Body text\n\n   More\n",
+		},
+		{
+			name:     "Custom title",
+			config:   RSTConfig{Aliases: aliases},
+			input:    "> [!danger] Stop\n> Body",
+			expected: ".. danger:: Stop\n\n   Body\n",
+		},
+		{
+			name:     "Alias resolves through the icon map",
+			config:   RSTConfig{Aliases: aliases},
+			input:    "> [!tips]\n> Body",
+			expected: ".. tip::\n   :class: tips\n\n   Body\n",
+		},
+		{
+			name:     "Other kinds use the fallback",
+			config:   RSTConfig{Aliases: aliases, Fallback: "warning"},
+			input:    "> [!bug]\n> Body",
+			expected: ".. warning::\n   :class: bug\n\n   Body\n",
+		},
+		{
+			name:     "Collapsible closed",
+			config:   RSTConfig{Aliases: aliases, FoldingEnabled: true, Collapsible: true},
+			input:    "> [!tip]- Hidden\n> Body",
+			expected: ".. tip:: Hidden\n   :collapsible: closed\n\n   Body\n",
+		},
+		{
+			name:     "Collapsible open",
+			config:   RSTConfig{Aliases: aliases, FoldingEnabled: true, Collapsible: true},
+			input:    "> [!tip]+\n> Body",
+			expected: ".. tip::\n   :collapsible: open\n\n   Body\n",
+		},
+		{
+			name:     "Collapsible ignored when folding is disabled",
+			config:   RSTConfig{Aliases: aliases, Collapsible: true},
+			input:    "> [!tip]-\n> Body",
+			expected: ".. tip::\n\n   Body\n",
+		},
+		{
+			name:     "Code blocks and nested callouts",
+			config:   RSTConfig{Aliases: aliases, Indent: 4},
+			input:    "> [!note]\n> ```go\n> x := 1\n> ```\n>\n> > [!tip]\n> > Inner",
+			expected: ".. note::\n\n    .. code-block:: go\n\n        x := 1\n\n    .. tip::\n\n        Inner\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewAlertsRSTRenderer(tc.config)
+			result := renderAlertsDocument(t, r, tc.input)
+			if result != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package renderer
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/zmtcreative/gm-alert-callouts/internal/constants"
+	gast "github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// SlackConfig holds the options for the Slack Block Kit renderer.
+type SlackConfig struct {
+	Aliases     map[string]string // Alias to primary kind map, used to resolve aliases to emoji
+	GFMKinds    map[string]string // GFM kind per kind, used when the map names neither the kind nor its primary
+	AllowNOICON bool              // Whether a 'noicon-' prefix suppresses the emoji
+	Emoji       map[string]string // Emoji per kind (constants.KIND_EMOJI when nil)
+}
+
+// SlackText is the text object of a Slack Block Kit block.
+type SlackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// SlackBlock is a Slack Block Kit section block.
+type SlackBlock struct {
+	Type string    `json:"type"`
+	Text SlackText `json:"text"`
+}
+
+// AlertsSlackRenderer renders each Alerts node as a Slack Block Kit section block with mrkdwn text:
+// the kind's emoji and the bold title, followed by the body as plain text.
+// Each block is written as one line of JSON, so the blocks of a document can be collected into
+// the 'blocks' array of a message.
+type AlertsSlackRenderer struct {
+	SlackConfig
+	titleCaser cases.Caser
+}
+
+// NewAlertsSlackRenderer returns a Slack Block Kit renderer for the Alerts, AlertsHeader and AlertsBody nodes.
+func NewAlertsSlackRenderer(config SlackConfig) renderer.NodeRenderer {
+	return newAlertsSlackRenderer(config, DetectLanguageTag())
+}
+
+// newAlertsSlackRenderer is an unexported constructor that allows injecting a language tag for tests.
+func newAlertsSlackRenderer(config SlackConfig, tag language.Tag) *AlertsSlackRenderer {
+	if config.Emoji == nil {
+		config.Emoji = constants.KIND_EMOJI
+	}
+	return &AlertsSlackRenderer{
+		SlackConfig: config,
+		titleCaser:  cases.Title(tag, cases.Compact),
+	}
+}
+
+func (r *AlertsSlackRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(constants.KindAlerts, r.renderAlerts)
+	reg.Register(constants.KindAlertsHeader, r.renderAlertsHeader)
+	reg.Register(constants.KindAlertsBody, r.renderAlertsBody)
+}
+
+func (r *AlertsSlackRenderer) renderAlerts(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	if !entering {
+		return gast.WalkContinue, nil
+	}
+	data, err := json.Marshal(r.Block(node, source))
+	if err != nil {
+		return gast.WalkStop, err
+	}
+	w.Write(data)
+	w.WriteString("\n")
+	return gast.WalkSkipChildren, nil
+}
+
+func (r *AlertsSlackRenderer) renderAlertsHeader(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	return gast.WalkSkipChildren, nil
+}
+
+func (r *AlertsSlackRenderer) renderAlertsBody(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	return gast.WalkSkipChildren, nil
+}
+
+// Block returns the section block for an Alerts node.
+func (r *AlertsSlackRenderer) Block(node gast.Node, source []byte) SlackBlock {
+	lines := r.mrkdwnLines(node, source, 0)
+	for i, line := range lines {
+		lines[i] = escapeSlack(line)
+	}
+	return SlackBlock{
+		Type: "section",
+		Text: SlackText{Type: "mrkdwn", Text: strings.Join(lines, "\n")},
+	}
+}
+
+// mrkdwnLines renders an Alerts node as unescaped mrkdwn lines; nested callouts are written as block quotes.
+func (r *AlertsSlackRenderer) mrkdwnLines(node gast.Node, source []byte, width int) []string {
+	attrs := readAlertAttributes(node)
+	title := alertTitle(node, source)
+	if title == "" {
+		title = r.titleCaser.String(attrs.Kind)
+	}
+
+	label := "*" + title + "*"
+	if !(r.AllowNOICON && attrs.NoIcon) {
+		if emoji := r.Emoji[resolveKind(attrs.Kind, r.Aliases, r.GFMKinds, r.Emoji)]; emoji != "" {
+			label = emoji + " " + label
+		}
+	}
+
+	lines := []string{label}
+	if body := alertBody(node); body != nil {
+		nested := func(node gast.Node, source []byte, width int) []string {
+			return prefixLines(r.mrkdwnLines(node, source, width), "> ", "> ")
+		}
+		lines = append(lines, blockLines(body, source, width, blockFormat{Alert: nested, Code: slackCodeLines})...)
+	}
+	return lines
+}
+
+// slackCodeLines writes a code block as a mrkdwn preformatted block.
+func slackCodeLines(code []string, language string) []string {
+	lines := append([]string{"```"}, code...)
+	return append(lines, "```")
+}
+
+var slackEscapes = strings.NewReplacer(
+	`&`, `&amp;`,
+	`<`, `&lt;`,
+	`>`, `&gt;`,
+)
+
+// escapeSlack escapes the characters Slack requires to be escaped in mrkdwn text.
+// A leading '> ' block quote marker is kept so nested callouts stay quoted.
+func escapeSlack(s string) string {
+	quote := ""
+	for strings.HasPrefix(s, "> ") || s == ">" {
+		quote += s[:min(2, len(s))]
+		s = s[min(2, len(s)):]
+	}
+	return quote + slackEscapes.Replace(s)
+}
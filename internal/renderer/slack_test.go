@@ -0,0 +1,66 @@
+package renderer
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestAlertsSlackRenderer(t *testing.T) {
+	aliases := map[string]string{"hint": "tip"}
+
+	testCases := []struct {
+		name     string
+		config   SlackConfig
+		input    string
+		expected string
+	}{
+		{
+			name:     "Emoji and bold title-cased kind",
+			config:   SlackConfig{Aliases: aliases},
+			input:    "> [!WARNING]\n> Body",
+			expected: "⚠️ *Warning*\nBody",
+		},
+		{
+			name:     "Alias resolves through the icon map",
+			config:   SlackConfig{Aliases: aliases},
+			input:    "> [!hint] Try a < b & c\n> Body",
+			expected: "💡 *Try a &lt; b &amp; c*\nBody",
+		},
+		{
+			name:     "NOICON suppresses the emoji",
+			config:   SlackConfig{Aliases: aliases, AllowNOICON: true},
+			input:    "> [!noicon-tip]\n> Body",
+			expected: "*Tip*\nBody",
+		},
+		{
+			name:     "Nested callouts and code",
+			config:   SlackConfig{Aliases: aliases, Emoji: map[string]string{}},
+			input:    "> [!note]\n> ```\n> a < b\n> ```\n>\n> > [!tip]\n> > Inner",
+			expected: "*Note*\n```\na &lt; b\n```\n\n> *Tip*\n> Inner",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newAlertsSlackRenderer(tc.config, language.English)
+			result := renderAlertsDocument(t, r, tc.input)
+
+			if strings.Count(result, "\n") != 1 || !strings.HasSuffix(result, "\n") {
+				t.Fatalf("Expected a single line of JSON, got %q", result)
+			}
+			var block SlackBlock
+			if err := json.Unmarshal([]byte(result), &block); err != nil {
+				t.Fatalf("Output is not valid JSON: %v", err)
+			}
+			if block.Type != "section" || block.Text.Type != "mrkdwn" {
+				t.Errorf("Expected a mrkdwn section block, got %+v", block)
+			}
+			if block.Text.Text != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, block.Text.Text)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package renderer
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/zmtcreative/gm-alert-callouts/internal/ast"
+	"github.com/zmtcreative/gm-alert-callouts/internal/constants"
+	gast "github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+// SPRITE_SYMBOL_PREFIX is prepended to the kind to build the id of its symbol in the sprite.
+const SPRITE_SYMBOL_PREFIX = "callout-icon-"
+
+var (
+	svgRootRegex = regexp.MustCompile(`(?s)^\s*<svg\b([^>]*)>(.*)</svg>\s*$`)
+	svgAttrRegex = regexp.MustCompile(`([\w:.-]+)\s*=\s*("[^"]*"|'[^']*')`)
+)
+
+// svgAttribute is a single attribute of an SVG root element, with its value still quoted.
+type svgAttribute struct {
+	Name  string
+	Value string
+}
+
+// splitSVG splits an inline SVG icon into the attributes of its root element and its content.
+// It reports false when icon is not a single <svg> element.
+func splitSVG(icon string) ([]svgAttribute, string, bool) {
+	m := svgRootRegex.FindStringSubmatch(icon)
+	if m == nil {
+		return nil, "", false
+	}
+	var attrs []svgAttribute
+	for _, a := range svgAttrRegex.FindAllStringSubmatch(m[1], -1) {
+		attrs = append(attrs, svgAttribute{Name: a[1], Value: a[2]})
+	}
+	return attrs, m[2], true
+}
+
+// writeSVGAttributes writes the attributes for which keep returns true, each preceded by a space.
+func writeSVGAttributes(sb *strings.Builder, attrs []svgAttribute, keep func(name string) bool) {
+	for _, a := range attrs {
+		if keep(a.Name) {
+			sb.WriteString(" " + a.Name + "=" + a.Value)
+		}
+	}
+}
+
+// symbolAttribute reports whether a root attribute of an icon is carried over to its <symbol>.
+// Sizing, identity and namespace attributes stay with the referencing <svg> or are dropped.
+func symbolAttribute(name string) bool {
+	switch {
+	case name == "width", name == "height", name == "class", name == "id", name == "version",
+		name == "x", name == "y", name == "role", name == "xmlns",
+		strings.HasPrefix(name, "xmlns:"), strings.HasPrefix(name, "aria-"):
+		return false
+	}
+	return true
+}
+
+// referenceAttribute reports whether a root attribute of an icon is carried over to the <svg>
+// that references its symbol, so existing CSS for the inline icons keeps working.
+func referenceAttribute(name string) bool {
+	return name == "width" || name == "height" || name == "class"
+}
+
+// SpriteSymbolID returns the id of the sprite symbol for kind.
+func SpriteSymbolID(kind string) string {
+	return SPRITE_SYMBOL_PREFIX + kind
+}
+
+// spriteKind returns the kind whose symbol is used for kind. Aliases share the symbol of their
+// primary kind as long as they still have the same icon.
+func spriteKind(kind string, icons map[string]string, aliases map[string]string) string {
+	if primary, ok := aliases[kind]; ok && icons[primary] == icons[kind] {
+		return primary
+	}
+	return kind
+}
+
+// headerIconKind returns the kind whose icon is shown in the header of a callout of the given kind:
+// the kind itself when it has an icon, otherwise (with custom alerts enabled) the first kind in
+// constants.FALLBACK_ICON_LIST that has one. An empty string means no icon is shown.
+func headerIconKind(kind string, icons map[string]string, customAlertsEnabled bool) string {
+	if icons[kind] != "" {
+		return kind
+	}
+	if customAlertsEnabled {
+		for _, v := range constants.FALLBACK_ICON_LIST {
+			if _, ok := icons[v]; ok {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+// spriteReference returns the <svg><use/></svg> markup that stands in for icon in a header,
+// or false when icon is not an SVG that can be placed in a sprite.
+func spriteReference(icon string, href string) (string, bool) {
+	attrs, _, ok := splitSVG(icon)
+	if !ok {
+		return "", false
+	}
+	var sb strings.Builder
+	sb.WriteString("<svg")
+	writeSVGAttributes(&sb, attrs, referenceAttribute)
+	sb.WriteString(`><use href="` + href + `"/></svg>`)
+	return sb.String(), true
+}
+
+// spriteSymbol converts an inline SVG icon into a <symbol> with the given id,
+// or reports false when icon is not an SVG.
+func spriteSymbol(id string, icon string) (string, bool) {
+	attrs, inner, ok := splitSVG(icon)
+	if !ok {
+		return "", false
+	}
+	var sb strings.Builder
+	sb.WriteString(`<symbol id="` + id + `"`)
+	writeSVGAttributes(&sb, attrs, symbolAttribute)
+	sb.WriteString(">" + inner + "</symbol>")
+	return sb.String(), true
+}
+
+// IconSprite returns a hidden <svg> sprite with one <symbol> per icon. Aliases share the symbol
+// of their primary kind. When kinds is nil every kind of icons is included, otherwise only the
+// symbols needed to render callouts of the given kinds (including any fallback icon).
+// Icons that are not SVG are left out, since headers write them inline.
+func IconSprite(icons map[string]string, aliases map[string]string, kinds []string, customAlertsEnabled bool) string {
+	if kinds == nil {
+		kinds = sortedKeys(icons)
+	}
+
+	var symbols []string
+	var seen []string
+	for _, kind := range kinds {
+		iconKind := headerIconKind(strings.ToLower(kind), icons, customAlertsEnabled)
+		if iconKind == "" {
+			continue
+		}
+		symbolKind := spriteKind(iconKind, icons, aliases)
+		if slices.Contains(seen, symbolKind) {
+			continue
+		}
+		seen = append(seen, symbolKind)
+		if symbol, ok := spriteSymbol(SpriteSymbolID(symbolKind), icons[symbolKind]); ok {
+			symbols = append(symbols, symbol)
+		}
+	}
+
+	if len(symbols) == 0 {
+		return ""
+	}
+	return `<svg xmlns="http://www.w3.org/2000/svg" style="display:none">` + "\n" +
+		strings.Join(symbols, "\n") + "\n</svg>\n"
+}
+
+// AlertsSpriteHTMLRenderer renders the AlertsSprite node as a hidden SVG sprite holding the
+// symbols that the callout headers of the document reference.
+type AlertsSpriteHTMLRenderer struct {
+	Icons               map[string]string
+	Aliases             map[string]string
+	CustomAlertsEnabled bool
+}
+
+// NewAlertsSpriteHTMLRenderer returns an HTML renderer for the AlertsSprite node.
+func NewAlertsSpriteHTMLRenderer(icons map[string]string, aliases map[string]string, customAlertsEnabled bool) renderer.NodeRenderer {
+	return &AlertsSpriteHTMLRenderer{
+		Icons:               icons,
+		Aliases:             aliases,
+		CustomAlertsEnabled: customAlertsEnabled,
+	}
+}
+
+func (r *AlertsSpriteHTMLRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(constants.KindAlertsSprite, r.renderAlertsSprite)
+}
+
+func (r *AlertsSpriteHTMLRenderer) renderAlertsSprite(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	if entering {
+		if sprite, ok := node.(*ast.AlertsSprite); ok {
+			w.WriteString(IconSprite(r.Icons, r.Aliases, sprite.Kinds, r.CustomAlertsEnabled))
+		}
+	}
+	return gast.WalkSkipChildren, nil
+}
@@ -0,0 +1,142 @@
+package renderer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zmtcreative/gm-alert-callouts/internal/ast"
+	"github.com/zmtcreative/gm-alert-callouts/internal/constants"
+	gast "github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"golang.org/x/text/language"
+)
+
+const spriteTestIcon = `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24" fill="none" stroke="currentColor" class="lucide lucide-info" aria-hidden="true"><circle cx="12" cy="12" r="10"/></svg>`
+
+func TestSpriteSymbol(t *testing.T) {
+	symbol, ok := spriteSymbol("callout-icon-note", spriteTestIcon)
+	if !ok {
+		t.Fatal("Expected the icon to be converted")
+	}
+	expected := `<symbol id="callout-icon-note" viewBox="0 0 24 24" fill="none" stroke="currentColor"><circle cx="12" cy="12" r="10"/></symbol>`
+	if symbol != expected {
+		t.Errorf("Expected %q, got %q", expected, symbol)
+	}
+
+	if _, ok := spriteSymbol("x", "<span>not svg</span>"); ok {
+		t.Error("Expected non-SVG icons to be rejected")
+	}
+}
+
+func TestSpriteReference(t *testing.T) {
+	ref, ok := spriteReference(spriteTestIcon, "#callout-icon-note")
+	if !ok {
+		t.Fatal("Expected the icon to be converted")
+	}
+	expected := `<svg width="24" height="24" class="lucide lucide-info"><use href="#callout-icon-note"/></svg>`
+	if ref != expected {
+		t.Errorf("Expected %q, got %q", expected, ref)
+	}
+}
+
+func TestIconSprite(t *testing.T) {
+	icons := map[string]string{
+		"note":    spriteTestIcon,
+		"info":    spriteTestIcon,
+		"tip":     `<svg viewBox="0 0 16 16"><path d="M1 1"/></svg>`,
+		"text":    "!",
+		"changed": `<svg viewBox="0 0 8 8"><path d="M2 2"/></svg>`,
+	}
+	aliases := map[string]string{"info": "note", "changed": "tip"}
+
+	t.Run("All kinds, aliases share a symbol", func(t *testing.T) {
+		sprite := IconSprite(icons, aliases, nil, false)
+		if !strings.HasPrefix(sprite, `<svg xmlns="http://www.w3.org/2000/svg" style="display:none">`) {
+			t.Errorf("Unexpected sprite start: %q", sprite)
+		}
+		for _, id := range []string{"callout-icon-note", "callout-icon-tip", "callout-icon-changed"} {
+			if strings.Count(sprite, `id="`+id+`"`) != 1 {
+				t.Errorf("Expected exactly one symbol %s in %q", id, sprite)
+			}
+		}
+		if strings.Contains(sprite, "callout-icon-info") {
+			t.Error("Expected the alias 'info' to share the 'note' symbol")
+		}
+		if strings.Contains(sprite, "callout-icon-text") {
+			t.Error("Expected non-SVG icons to be left out")
+		}
+	})
+
+	t.Run("Only the kinds used, with fallback", func(t *testing.T) {
+		sprite := IconSprite(icons, aliases, []string{"INFO", "custom"}, true)
+		if strings.Count(sprite, "<symbol") != 1 || !strings.Contains(sprite, `id="callout-icon-note"`) {
+			t.Errorf("Expected only the 'note' symbol, got %q", sprite)
+		}
+	})
+
+	t.Run("Empty when nothing is needed", func(t *testing.T) {
+		if sprite := IconSprite(icons, aliases, []string{"custom"}, false); sprite != "" {
+			t.Errorf("Expected an empty sprite, got %q", sprite)
+		}
+	})
+}
+
+func TestAlertsSpriteHTMLRenderer(t *testing.T) {
+	r := NewAlertsSpriteHTMLRenderer(map[string]string{"note": spriteTestIcon}, nil, false)
+
+	registrations := make(map[gast.NodeKind]renderer.NodeRendererFunc)
+	r.(*AlertsSpriteHTMLRenderer).RegisterFuncs(&mockNodeRendererFuncRegisterer{registrations: registrations})
+	fn, ok := registrations[constants.KindAlertsSprite]
+	if !ok {
+		t.Fatal("Expected a registration for KindAlertsSprite")
+	}
+
+	w := newMockBufWriter()
+	status, err := fn(w, nil, ast.NewAlertsSprite([]string{"note"}), true)
+	if err != nil || status != gast.WalkSkipChildren {
+		t.Errorf("Unexpected result: %v, %v", status, err)
+	}
+	if !strings.Contains(w.String(), `<symbol id="callout-icon-note"`) {
+		t.Errorf("Expected the note symbol, got %q", w.String())
+	}
+}
+
+func TestAlertsHeaderSpriteMode(t *testing.T) {
+	icons := map[string]string{"note": spriteTestIcon, "info": spriteTestIcon, "text": "<b>!</b>"}
+
+	newHeader := func(url string) *AlertsHeaderHTMLRenderer {
+		r := newAlertsHeaderHTMLRenderer(icons, true, constants.ICONS_NONE, true, true, language.English).(*AlertsHeaderHTMLRenderer)
+		r.IconMode = constants.ICON_MODE_SPRITE
+		r.Aliases = map[string]string{"info": "note"}
+		r.SpriteURL = url
+		return r
+	}
+
+	testCases := []struct {
+		name     string
+		url      string
+		kind     string
+		expected string
+	}{
+		{"Alias uses the primary symbol", "", "info", `<svg width="24" height="24" class="lucide lucide-info"><use href="#callout-icon-note"/></svg>`},
+		{"External sprite URL", "/icons.svg", "note", `<use href="/icons.svg#callout-icon-note"/>`},
+		{"Fallback icon", "", "custom", `<use href="#callout-icon-note"/>`},
+		{"Non-SVG icons stay inline", "", "text", `<div class="callout-title">` + "\n<b>!</b>"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := newMockBufWriter()
+			node := createMockHeaderNode(tc.kind, false, "")
+			if _, err := newHeader(tc.url).renderAlertsHeader(w, nil, node, true); err != nil {
+				t.Fatalf("renderAlertsHeader returned error: %v", err)
+			}
+			if !strings.Contains(w.String(), tc.expected) {
+				t.Errorf("Expected %q in %q", tc.expected, w.String())
+			}
+			if tc.kind != "text" && strings.Contains(w.String(), "<circle") {
+				t.Errorf("Expected no inline SVG content, got %q", w.String())
+			}
+		})
+	}
+}
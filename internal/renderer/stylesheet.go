@@ -0,0 +1,294 @@
+package renderer
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/zmtcreative/gm-alert-callouts/internal/constants"
+)
+
+// Palette holds the accent color of a kind for light and dark color schemes as CSS color values.
+type Palette struct {
+	Light string
+	Dark  string
+}
+
+// GFMStrictPalettes holds the GitHub alert colors for the GFM Strict icon set.
+var GFMStrictPalettes = map[string]Palette{
+	"note":      {"#0969da", "#4493f8"},
+	"tip":       {"#1a7f37", "#3fb950"},
+	"important": {"#8250df", "#ab7df8"},
+	"warning":   {"#9a6700", "#d29922"},
+	"caution":   {"#d1242f", "#f85149"},
+}
+
+// HybridPalettes holds the colors of the Hybrid icon set, matching examples/assets/css/alertcallouts-hybrid.css.
+var HybridPalettes = map[string]Palette{
+	"note":      {"#086ddd", "#027aff"},
+	"tip":       {"#00bfbc", "#53dfdd"},
+	"important": {"#7f00ff", "#a882ff"},
+	"warning":   {"#ec7500", "#e9973f"},
+	"caution":   {"#e93147", "#fb464c"},
+	"bug":       {"#e93147", "#fb464c"},
+	"example":   {"#7852ee", "#a882ff"},
+	"failure":   {"#e93147", "#fb464c"},
+	"question":  {"#ec7500", "#e9973f"},
+	"quote":     {"#9e9e9e", "#9e9e9e"},
+	"scroll":    {"#00bfbc", "#53dfdd"},
+	"success":   {"#08b94e", "#44cf6e"},
+	"summary":   {"#00bfbc", "#53dfdd"},
+	"todo":      {"#086ddd", "#027aff"},
+}
+
+// ObsidianPalettes holds the colors of Obsidian's default callouts for the Obsidian icon set.
+var ObsidianPalettes = map[string]Palette{
+	"note":     {"#086ddd", "#027aff"},
+	"abstract": {"#00bfbc", "#53dfdd"},
+	"info":     {"#086ddd", "#027aff"},
+	"todo":     {"#086ddd", "#027aff"},
+	"tip":      {"#00bfbc", "#53dfdd"},
+	"success":  {"#08b94e", "#44cf6e"},
+	"question": {"#ec7500", "#e9973f"},
+	"warning":  {"#ec7500", "#e9973f"},
+	"failure":  {"#e93147", "#fb464c"},
+	"danger":   {"#e93147", "#fb464c"},
+	"bug":      {"#e93147", "#fb464c"},
+	"example":  {"#7852ee", "#a882ff"},
+	"quote":    {"#9e9e9e", "#9e9e9e"},
+}
+
+// DefaultPalette is used for kinds that have no palette of their own, such as custom kinds.
+var DefaultPalette = Palette{"#086ddd", "#027aff"}
+
+// DefaultPalettes returns a copy of the palettes for the given built-in icon set (constants.ICONS_*).
+// Custom icon sets get the Hybrid palettes, completed with the Obsidian kinds that Hybrid lacks.
+func DefaultPalettes(defaultIcons int) map[string]Palette {
+	var sources []map[string]Palette
+	switch defaultIcons {
+	case constants.ICONS_GFM:
+		sources = []map[string]Palette{GFMStrictPalettes}
+	case constants.ICONS_HYBRID:
+		sources = []map[string]Palette{HybridPalettes}
+	case constants.ICONS_OBSIDIAN:
+		sources = []map[string]Palette{ObsidianPalettes}
+	default:
+		sources = []map[string]Palette{ObsidianPalettes, HybridPalettes}
+	}
+
+	palettes := make(map[string]Palette)
+	for _, source := range sources {
+		for kind, palette := range source {
+			palettes[kind] = palette
+		}
+	}
+	return palettes
+}
+
+// StylesheetConfig holds the options for the stylesheet generator.
+type StylesheetConfig struct {
+	Icons               map[string]string  // Icon map; every kind in it gets a color rule
+	Kinds               []string           // Kinds that get a color rule besides those of the icon map, such as custom kinds with a palette
+	Aliases             map[string]string  // Alias to primary kind map, so aliases use the palette of their primary
+	GFMKinds            map[string]string  // GFM kind per kind, whose palette a kind without one uses
+	DefaultIcons        int                // Built-in icon set (constants.ICONS_*), for the default palettes
+	IconSet             string             // Name of the icon set for the iconset-<name> rule (from DefaultIcons when empty)
+	FoldingEnabled      bool               // Whether rules for foldable <details> callouts are written
+	CustomAlertsEnabled bool               // Whether custom kinds may appear (adds the fallback icon in CSS icon mode)
+	IconMode            int                // Icon mode (constants.ICON_MODE_*); the CSS mode adds the icon stylesheet
+	CanonicalKinds      bool               // Whether aliases render as their primary kind, so they need no color rule
+	Palettes            map[string]Palette // Palette per kind (DefaultPalettes(DefaultIcons) when nil)
+	Default             Palette            // Palette for kinds without one (DefaultPalette when empty)
+	DarkSelector        string             // Selector that enables the dark palette (prefers-color-scheme when empty)
+}
+
+// Stylesheet returns a complete stylesheet for the callouts described by config.
+// Colors are set as CSS custom properties on :root (--callout-color-<kind>), switched to the dark
+// palette by a prefers-color-scheme media query or by config.DarkSelector, and each callout
+// picks its color through the --callout-color property.
+func Stylesheet(config StylesheetConfig) string {
+	palettes := config.Palettes
+	if palettes == nil {
+		palettes = DefaultPalettes(config.DefaultIcons)
+	}
+	def := config.Default
+	if def == (Palette{}) {
+		def = DefaultPalette
+	}
+
+	// Group every kind of the icon set under the palette it resolves to.
+	groups := make(map[string][]string)
+	kinds := append(sortedKeys(config.Icons), config.Kinds...)
+	slices.Sort(kinds)
+	for _, kind := range slices.Compact(kinds) {
+		if _, isAlias := config.Aliases[kind]; isAlias && config.CanonicalKinds {
+			continue
+		}
+		if key := resolveKind(kind, config.Aliases, config.GFMKinds, palettes); key != "" {
+			groups[key] = append(groups[key], kind)
+		}
+	}
+	used := sortedKeys(groups)
+
+	var sb strings.Builder
+
+	sb.WriteString(":root {\n")
+	sb.WriteString("  --callout-radius: 6px;\n")
+	sb.WriteString("  --callout-border-width: 1px;\n")
+	sb.WriteString("  --callout-accent-width: 4px;\n")
+	sb.WriteString("  --callout-padding: 0.75em 1em;\n")
+	sb.WriteString("  --callout-background-mix: 8%;\n")
+	sb.WriteString("  --callout-title-weight: 600;\n")
+	writeColorProperties(&sb, "  ", def.Light, used, palettes, func(p Palette) string { return p.Light })
+	sb.WriteString("}\n\n")
+
+	if config.DarkSelector == "" {
+		sb.WriteString("@media (prefers-color-scheme: dark) {\n  :root {\n")
+		writeColorProperties(&sb, "    ", def.Dark, used, palettes, func(p Palette) string { return p.Dark })
+		sb.WriteString("  }\n}\n\n")
+	} else {
+		sb.WriteString(config.DarkSelector + " {\n")
+		writeColorProperties(&sb, "  ", def.Dark, used, palettes, func(p Palette) string { return p.Dark })
+		sb.WriteString("}\n\n")
+	}
+
+	sb.WriteString(`.callout {
+  --callout-color: var(--callout-color-default);
+  margin: 1em 0;
+  padding: var(--callout-padding);
+  border: var(--callout-border-width) solid color-mix(in srgb, var(--callout-color) 35%, transparent);
+  border-left: var(--callout-accent-width) solid var(--callout-color);
+  border-radius: var(--callout-radius);
+  background-color: color-mix(in srgb, var(--callout-color) var(--callout-background-mix), transparent);
+}
+`)
+
+	for _, key := range used {
+		selectors := make([]string, len(groups[key]))
+		for i, kind := range groups[key] {
+			selectors[i] = ".callout-" + kind
+		}
+		sb.WriteString("\n" + strings.Join(selectors, ",\n") + " {\n")
+		sb.WriteString("  --callout-color: var(--callout-color-" + key + ");\n")
+		sb.WriteString("}\n")
+	}
+
+	sb.WriteString(`
+.callout-title {
+  display: flex;
+  align-items: center;
+  gap: 0.4em;
+  color: var(--callout-color);
+  font-weight: var(--callout-title-weight);
+}
+.callout-title > svg,
+.callout-title > .callout-icon {
+  flex-shrink: 0;
+  width: 1.25em;
+  height: 1.25em;
+}
+.callout-title-text {
+  margin: 0;
+}
+.callout-body > :first-child {
+  margin-top: 0.5em;
+}
+.callout-body > :last-child {
+  margin-bottom: 0;
+}
+`)
+
+	if config.FoldingEnabled {
+		sb.WriteString(`
+details.callout > summary.callout-title {
+  cursor: pointer;
+  list-style: none;
+}
+details.callout > summary.callout-title::-webkit-details-marker {
+  display: none;
+}
+details.callout > summary.callout-title::after {
+  content: "\25B8";
+  margin-left: auto;
+  transition: transform 0.15s ease-in-out;
+}
+details.callout[open] > summary.callout-title::after {
+  transform: rotate(90deg);
+}
+details.callout:not([open]) {
+  padding-bottom: 0.75em;
+}
+`)
+	}
+
+	iconset := config.IconSet
+	if iconset == "" {
+		iconset = iconSetName(config.DefaultIcons)
+	}
+	if iconset != "" {
+		sb.WriteString("\n" + iconSetRule(iconset))
+	}
+
+	if config.IconMode == constants.ICON_MODE_CSS {
+		sb.WriteString("\n" + IconStylesheet(config.Icons, config.CustomAlertsEnabled))
+	}
+
+	return sb.String()
+}
+
+// iconSetRules holds the rules for the iconset classes of the built-in icon sets.
+var iconSetRules = map[string]string{
+	"gfm": `.callout.iconset-gfm {
+  border-width: 0 0 0 var(--callout-accent-width);
+  border-radius: 0;
+  background-color: transparent;
+}
+`,
+	"hybrid": `.callout.iconset-hybrid {
+  --callout-radius: 10px;
+}
+`,
+	"obsidian": `.callout.iconset-obsidian {
+  --callout-background-mix: 10%;
+  border-width: 0;
+  border-radius: 4px;
+}
+`,
+	"emoji": `.callout.iconset-emoji .callout-icon-emoji {
+  flex-shrink: 0;
+  font-size: 1.1em;
+  line-height: 1;
+}
+`,
+}
+
+// iconSetName returns the name of a built-in icon set (constants.ICONS_*), or "" for none.
+func iconSetName(defaultIcons int) string {
+	switch defaultIcons {
+	case constants.ICONS_GFM:
+		return "gfm"
+	case constants.ICONS_HYBRID:
+		return "hybrid"
+	case constants.ICONS_OBSIDIAN:
+		return "obsidian"
+	case constants.ICONS_EMOJI:
+		return "emoji"
+	}
+	return ""
+}
+
+// iconSetRule returns the rule for the iconset-<name> class. Icon sets other than the built-in
+// ones get the default layout properties, which stylesheets can adjust for the set in one place.
+func iconSetRule(name string) string {
+	if rule, ok := iconSetRules[name]; ok {
+		return rule
+	}
+	return ".callout.iconset-" + name + " {\n  --callout-radius: 6px;\n  --callout-background-mix: 8%;\n}\n"
+}
+
+// writeColorProperties writes the --callout-color-* custom properties for the default and the used palettes.
+func writeColorProperties(sb *strings.Builder, indent string, def string, used []string, palettes map[string]Palette, color func(Palette) string) {
+	sb.WriteString(indent + "--callout-color-default: " + def + ";\n")
+	for _, key := range used {
+		sb.WriteString(indent + "--callout-color-" + key + ": " + color(palettes[key]) + ";\n")
+	}
+}
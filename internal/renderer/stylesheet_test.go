@@ -0,0 +1,142 @@
+package renderer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zmtcreative/gm-alert-callouts/internal/constants"
+)
+
+func TestDefaultPalettes(t *testing.T) {
+	if p := DefaultPalettes(constants.ICONS_GFM); len(p) != 5 || p["caution"] != GFMStrictPalettes["caution"] {
+		t.Errorf("Unexpected GFM palettes: %v", p)
+	}
+	if p := DefaultPalettes(constants.ICONS_OBSIDIAN); p["info"] != ObsidianPalettes["info"] {
+		t.Errorf("Expected the Obsidian 'info' palette, got %v", p["info"])
+	}
+
+	custom := DefaultPalettes(constants.ICONS_NONE)
+	if custom["caution"] != HybridPalettes["caution"] || custom["danger"] != ObsidianPalettes["danger"] {
+		t.Errorf("Expected Hybrid palettes completed with Obsidian kinds, got %v", custom)
+	}
+
+	custom["note"] = Palette{"red", "red"}
+	if HybridPalettes["note"].Light == "red" || ObsidianPalettes["note"].Light == "red" {
+		t.Error("Expected DefaultPalettes to return a copy")
+	}
+}
+
+func TestStylesheet(t *testing.T) {
+	icons := map[string]string{
+		"note":   "<svg>note</svg>",
+		"info":   "<svg>note</svg>",
+		"tip":    "<svg>tip</svg>",
+		"custom": "<svg>custom</svg>",
+	}
+
+	t.Run("Canonical kinds need no alias selectors", func(t *testing.T) {
+		css := Stylesheet(StylesheetConfig{
+			Icons:          icons,
+			Aliases:        map[string]string{"info": "note"},
+			DefaultIcons:   constants.ICONS_HYBRID,
+			CanonicalKinds: true,
+		})
+		if strings.Contains(css, ".callout-info") || !strings.Contains(css, ".callout-note {\n") {
+			t.Errorf("Expected a rule for note only, got:\n%s", css)
+		}
+	})
+
+	t.Run("Light and dark palettes with aliases", func(t *testing.T) {
+		css := Stylesheet(StylesheetConfig{
+			Icons:        icons,
+			Aliases:      map[string]string{"info": "note"},
+			DefaultIcons: constants.ICONS_HYBRID,
+		})
+
+		for _, expected := range []string{
+			"  --callout-color-note: #086ddd;\n",
+			"@media (prefers-color-scheme: dark) {\n  :root {\n    --callout-color-default: #027aff;\n    --callout-color-note: #027aff;\n",
+			".callout-info,\n.callout-note {\n  --callout-color: var(--callout-color-note);\n}\n",
+			".callout-tip {\n  --callout-color: var(--callout-color-tip);\n}\n",
+			".callout.iconset-hybrid {",
+		} {
+			if !strings.Contains(css, expected) {
+				t.Errorf("Expected %q in:\n%s", expected, css)
+			}
+		}
+		if strings.Contains(css, "callout-color-custom") || strings.Contains(css, "details.callout") {
+			t.Errorf("Expected no rule for kinds without a palette and no folding rules, got:\n%s", css)
+		}
+		if strings.Contains(css, "--callout-color-bug") {
+			t.Errorf("Expected properties only for the palettes in use, got:\n%s", css)
+		}
+	})
+
+	t.Run("Kinds with a palette but no icon", func(t *testing.T) {
+		css := Stylesheet(StylesheetConfig{
+			Icons:    icons,
+			Kinds:    []string{"security", "note"},
+			Palettes: map[string]Palette{"note": {"#111111", "#222222"}, "security": {"#333333", "#444444"}},
+		})
+		for _, expected := range []string{
+			"  --callout-color-security: #333333;\n",
+			"    --callout-color-security: #444444;\n",
+			".callout-security {\n  --callout-color: var(--callout-color-security);\n}\n",
+		} {
+			if !strings.Contains(css, expected) {
+				t.Errorf("Expected %q in:\n%s", expected, css)
+			}
+		}
+		if strings.Count(css, ".callout-note {") != 1 {
+			t.Errorf("Expected a single rule for note, got:\n%s", css)
+		}
+	})
+
+	t.Run("Iconset rules", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			config   StylesheetConfig
+			expected string
+		}{
+			{"Emoji preset", StylesheetConfig{DefaultIcons: constants.ICONS_EMOJI}, ".callout.iconset-emoji .callout-icon-emoji {\n"},
+			{"Registered icon set", StylesheetConfig{IconSet: "brand"}, ".callout.iconset-brand {\n  --callout-radius: 6px;\n"},
+			{"Name wins over the preset", StylesheetConfig{DefaultIcons: constants.ICONS_HYBRID, IconSet: "brand"}, ".callout.iconset-brand {\n"},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				css := Stylesheet(tc.config)
+				if !strings.Contains(css, tc.expected) {
+					t.Errorf("Expected %q in:\n%s", tc.expected, css)
+				}
+				if strings.Count(css, ".iconset-") != 1 {
+					t.Errorf("Expected a single iconset rule, got:\n%s", css)
+				}
+			})
+		}
+	})
+
+	t.Run("Overrides, dark selector, folding and CSS icons", func(t *testing.T) {
+		css := Stylesheet(StylesheetConfig{
+			Icons:          map[string]string{"brand": `<svg viewBox="0 0 1 1"></svg>`},
+			FoldingEnabled: true,
+			IconMode:       constants.ICON_MODE_CSS,
+			Palettes:       map[string]Palette{"brand": {"#111111", "#eeeeee"}},
+			Default:        Palette{"gray", "silver"},
+			DarkSelector:   `[data-theme="dark"]`,
+		})
+
+		for _, expected := range []string{
+			"  --callout-color-default: gray;\n  --callout-color-brand: #111111;\n",
+			"[data-theme=\"dark\"] {\n  --callout-color-default: silver;\n  --callout-color-brand: #eeeeee;\n}\n",
+			"details.callout[open] > summary.callout-title::after {",
+			".callout-brand > .callout-title > .callout-icon {",
+		} {
+			if !strings.Contains(css, expected) {
+				t.Errorf("Expected %q in:\n%s", expected, css)
+			}
+		}
+		if strings.Contains(css, "prefers-color-scheme") || strings.Contains(css, "iconset-") {
+			t.Errorf("Expected no media query and no iconset rule, got:\n%s", css)
+		}
+	})
+}
@@ -0,0 +1,200 @@
+package renderer
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/zmtcreative/gm-alert-callouts/internal/constants"
+	gast "github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// TerminalBorder holds the characters used to draw the box around a callout.
+type TerminalBorder struct {
+	TopLeft     string
+	TopRight    string
+	BottomLeft  string
+	BottomRight string
+	Horizontal  string
+	Vertical    string
+}
+
+var (
+	// TerminalBorderRounded draws boxes with Unicode box-drawing characters and rounded corners.
+	TerminalBorderRounded = TerminalBorder{"╭", "╮", "╰", "╯", "─", "│"}
+	// TerminalBorderASCII draws boxes with plain ASCII characters.
+	TerminalBorderASCII = TerminalBorder{"+", "+", "+", "+", "-", "|"}
+)
+
+// TerminalConfig holds the options for the ANSI terminal renderer.
+type TerminalConfig struct {
+	Aliases        map[string]string // Alias to primary kind map, used to resolve aliases to colors and glyphs
+	GFMKinds       map[string]string // GFM kind per kind, used when the map names neither the kind nor its primary
+	FoldingEnabled bool              // Whether folding functionality is enabled
+	AllowNOICON    bool              // Whether a 'noicon-' prefix suppresses the glyph
+	Colors         map[string]string // ANSI SGR color parameter per kind (constants.KIND_ANSI_COLOR when nil)
+	Glyphs         map[string]string // Glyph per kind (constants.KIND_GLYPH when nil)
+	Color          bool              // Whether ANSI escape sequences are written
+	Border         TerminalBorder    // Characters used to draw the box
+	Width          int               // Total width of the box in columns
+}
+
+// AlertsTerminalRenderer renders the alert nodes as bordered boxes for display in a terminal.
+// Folded callouts are drawn collapsed, with a hint showing how many lines are hidden.
+type AlertsTerminalRenderer struct {
+	TerminalConfig
+	titleCaser cases.Caser
+}
+
+// NewAlertsTerminalRenderer returns a terminal renderer for the Alerts, AlertsHeader and AlertsBody nodes.
+func NewAlertsTerminalRenderer(config TerminalConfig) renderer.NodeRenderer {
+	return newAlertsTerminalRenderer(config, DetectLanguageTag())
+}
+
+// newAlertsTerminalRenderer is an unexported constructor that allows injecting a language tag for tests.
+func newAlertsTerminalRenderer(config TerminalConfig, tag language.Tag) *AlertsTerminalRenderer {
+	if config.Border == (TerminalBorder{}) {
+		config.Border = TerminalBorderRounded
+	}
+	return &AlertsTerminalRenderer{
+		TerminalConfig: config,
+		titleCaser:     cases.Title(tag, cases.Compact),
+	}
+}
+
+// ColorSupported reports whether ANSI colors should be written to f.
+// Colors are disabled when the NO_COLOR environment variable is set (see https://no-color.org),
+// when TERM is "dumb", or when f is not a terminal.
+func ColorSupported(f *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" || f == nil {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+func (r *AlertsTerminalRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(constants.KindAlerts, r.renderAlerts)
+	reg.Register(constants.KindAlertsHeader, r.renderAlertsHeader)
+	reg.Register(constants.KindAlertsBody, r.renderAlertsBody)
+}
+
+// renderAlerts draws the whole callout itself, since every body line has to be framed by the box.
+func (r *AlertsTerminalRenderer) renderAlerts(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	if !entering {
+		return gast.WalkContinue, nil
+	}
+	writeLines(w, r.boxLines(node, source, r.Width))
+	if node.NextSibling() != nil {
+		w.WriteString("\n")
+	}
+	return gast.WalkSkipChildren, nil
+}
+
+func (r *AlertsTerminalRenderer) renderAlertsHeader(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	if entering {
+		attrs := readAlertAttributes(node)
+		w.WriteString(r.paint(attrs.Kind, "1;", r.label(node, source)))
+		w.WriteString("\n")
+	}
+	return gast.WalkSkipChildren, nil
+}
+
+func (r *AlertsTerminalRenderer) renderAlertsBody(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	if entering {
+		writeLines(w, blockLines(node, source, r.Width, blockFormat{Alert: r.boxLines}))
+	}
+	return gast.WalkSkipChildren, nil
+}
+
+// boxLines draws an Alerts node, including any nested callouts, as a box width columns wide.
+func (r *AlertsTerminalRenderer) boxLines(node gast.Node, source []byte, width int) []string {
+	attrs := readAlertAttributes(node)
+	b := r.Border
+	inner := max(width-4, 1)
+
+	var body []string
+	if n := alertBody(node); n != nil {
+		body = blockLines(n, source, inner, blockFormat{Alert: r.boxLines})
+	}
+
+	label := r.label(node, source)
+	if r.FoldingEnabled && attrs.ShouldFold {
+		if attrs.Closed {
+			label = "▸ " + label
+		} else {
+			label = "▾ " + label
+		}
+	}
+
+	fill := func(used int) string {
+		return strings.Repeat(b.Horizontal, max(width-used, 1))
+	}
+
+	lines := []string{
+		r.paint(attrs.Kind, "", b.TopLeft+b.Horizontal+" ") + r.paint(attrs.Kind, "1;", label) +
+			r.paint(attrs.Kind, "", " "+fill(5+displayWidth(label))+b.TopRight),
+	}
+
+	if r.FoldingEnabled && attrs.ShouldFold && attrs.Closed {
+		hint := fmt.Sprintf("%d lines folded", len(body))
+		if len(body) == 1 {
+			hint = "1 line folded"
+		}
+		return append(lines, r.paint(attrs.Kind, "", b.BottomLeft+b.Horizontal+" "+hint+" "+fill(5+displayWidth(hint))+b.BottomRight))
+	}
+
+	side := r.paint(attrs.Kind, "", b.Vertical)
+	for _, line := range body {
+		// Words and code lines longer than the box are broken so the right border stays in place.
+		for _, part := range breakLine(line, inner) {
+			pad := strings.Repeat(" ", max(inner-displayWidth(part), 0))
+			lines = append(lines, side+" "+part+pad+" "+side)
+		}
+	}
+	return append(lines, r.paint(attrs.Kind, "", b.BottomLeft+fill(2)+b.BottomRight))
+}
+
+// label builds the title of a callout: the glyph for its kind followed by the custom title
+// or, when there is none, the title-cased kind.
+func (r *AlertsTerminalRenderer) label(node gast.Node, source []byte) string {
+	attrs := readAlertAttributes(node)
+	label := alertTitle(node, source)
+	if label == "" {
+		label = r.titleCaser.String(attrs.Kind)
+	}
+	if !(r.AllowNOICON && attrs.NoIcon) {
+		glyphs := r.Glyphs
+		if glyphs == nil {
+			glyphs = constants.KIND_GLYPH
+		}
+		if glyph := glyphs[resolveKind(attrs.Kind, r.Aliases, r.GFMKinds, glyphs)]; glyph != "" {
+			label = glyph + " " + label
+		}
+	}
+	return label
+}
+
+// paint wraps s in the ANSI color for kind, with any extra SGR parameters (such as "1;" for bold)
+// placed before the color. Nothing is added when colors are disabled or the kind has no color.
+func (r *AlertsTerminalRenderer) paint(kind string, extra string, s string) string {
+	if !r.Color {
+		return s
+	}
+	colors := r.Colors
+	if colors == nil {
+		colors = constants.KIND_ANSI_COLOR
+	}
+	color := colors[resolveKind(kind, r.Aliases, r.GFMKinds, colors)]
+	if color == "" {
+		return s
+	}
+	return "\x1b[" + extra + color + "m" + s + "\x1b[0m"
+}
@@ -0,0 +1,170 @@
+package renderer
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestAlertsTerminalRendererBox(t *testing.T) {
+	r := newAlertsTerminalRenderer(TerminalConfig{Width: 20}, language.English)
+	result := renderAlertsDocument(t, r, "> [!note]\n> Body text")
+
+	expected := "" +
+		"╭─ ℹ Note ─────────╮\n" +
+		"│ Body text        │\n" +
+		"╰──────────────────╯\n"
+	if result != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, result)
+	}
+}
+
+func TestAlertsTerminalRendererLongWord(t *testing.T) {
+	r := newAlertsTerminalRenderer(TerminalConfig{Width: 20}, language.English)
+	result := renderAlertsDocument(t, r, "> [!note]\n> a averyveryverylongwordthatdoesnotfit")
+
+	expected := "" +
+		"╭─ ℹ Note ─────────╮\n" +
+		"│ a                │\n" +
+		"│ averyveryverylon │\n" +
+		"│ gwordthatdoesnot │\n" +
+		"│ fit              │\n" +
+		"╰──────────────────╯\n"
+	if result != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, result)
+	}
+}
+
+func TestAlertsTerminalRendererASCIIAndTitle(t *testing.T) {
+	r := newAlertsTerminalRenderer(TerminalConfig{Width: 24, Border: TerminalBorderASCII, Glyphs: map[string]string{}}, language.English)
+	result := renderAlertsDocument(t, r, "> [!tip] Custom title\n> alpha beta gamma delta")
+
+	expected := "" +
+		"+- Custom title -------+\n" +
+		"| alpha beta gamma     |\n" +
+		"| delta                |\n" +
+		"+----------------------+\n"
+	if result != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, result)
+	}
+}
+
+func TestAlertsTerminalRendererFolding(t *testing.T) {
+	t.Run("Closed callout is collapsed with a hint", func(t *testing.T) {
+		r := newAlertsTerminalRenderer(TerminalConfig{Width: 24, FoldingEnabled: true, Border: TerminalBorderASCII}, language.English)
+		result := renderAlertsDocument(t, r, "> [!warning]-\n> one\n>\n> two")
+
+		expected := "" +
+			"+- ▸ ⚠ Warning --------+\n" +
+			"+- 3 lines folded -----+\n"
+		if result != expected {
+			t.Errorf("Expected:\n%s\nGot:\n%s", expected, result)
+		}
+	})
+
+	t.Run("Open callout shows its body", func(t *testing.T) {
+		r := newAlertsTerminalRenderer(TerminalConfig{Width: 24, FoldingEnabled: true, Border: TerminalBorderASCII}, language.English)
+		result := renderAlertsDocument(t, r, "> [!warning]+\n> one")
+
+		if !strings.HasPrefix(result, "+- ▾ ⚠ Warning") || !strings.Contains(result, "| one") {
+			t.Errorf("Expected an expanded box, got:\n%s", result)
+		}
+	})
+
+	t.Run("Folding disabled ignores fold state", func(t *testing.T) {
+		r := newAlertsTerminalRenderer(TerminalConfig{Width: 24, Border: TerminalBorderASCII}, language.English)
+		result := renderAlertsDocument(t, r, "> [!warning]-\n> one")
+
+		if strings.Contains(result, "folded") || !strings.Contains(result, "| one") {
+			t.Errorf("Expected an expanded box, got:\n%s", result)
+		}
+	})
+}
+
+func TestAlertsTerminalRendererColor(t *testing.T) {
+	aliases := map[string]string{"info": "note"}
+	r := newAlertsTerminalRenderer(TerminalConfig{Width: 20, Color: true, Aliases: aliases, Colors: map[string]string{"note": "34"}}, language.English)
+	result := renderAlertsDocument(t, r, "> [!info]\n> Body")
+
+	if !strings.Contains(result, "\x1b[1;34mℹ Info\x1b[0m") {
+		t.Errorf("Expected bold colored label resolved through the alias, got %q", result)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(result, "\n"), "\n")
+	for _, line := range lines {
+		if displayWidth(line) != 20 {
+			t.Errorf("Expected every line to be 20 columns wide, got %d for %q", displayWidth(line), line)
+		}
+	}
+}
+
+func TestAlertsTerminalRendererNested(t *testing.T) {
+	r := newAlertsTerminalRenderer(TerminalConfig{Width: 20, Border: TerminalBorderASCII, Glyphs: map[string]string{}}, language.English)
+	result := renderAlertsDocument(t, r, "> [!note]\n> > [!tip]\n> > In")
+
+	expected := "" +
+		"+- Note -----------+\n" +
+		"| +- Tip --------+ |\n" +
+		"| | In           | |\n" +
+		"| +--------------+ |\n" +
+		"+------------------+\n"
+	if result != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, result)
+	}
+}
+
+func TestColorSupported(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	if ColorSupported(os.Stdout) {
+		t.Error("Expected no color support when NO_COLOR is set")
+	}
+
+	t.Setenv("NO_COLOR", "")
+	if ColorSupported(nil) {
+		t.Error("Expected no color support for a nil file")
+	}
+
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if ColorSupported(f) {
+		t.Error("Expected no color support for a regular file")
+	}
+}
+
+func TestDisplayWidth(t *testing.T) {
+	testCases := map[string]int{
+		"abc":                3,
+		"💡":                  2,
+		"ℹ️":                 1,
+		"\x1b[1;34mab\x1b[0m": 2,
+	}
+	for s, expected := range testCases {
+		if got := displayWidth(s); got != expected {
+			t.Errorf("displayWidth(%q) = %d, expected %d", s, got, expected)
+		}
+	}
+}
+
+func TestBreakLine(t *testing.T) {
+	testCases := []struct {
+		line     string
+		width    int
+		expected []string
+	}{
+		{"short", 10, []string{"short"}},
+		{"abcdefgh", 3, []string{"abc", "def", "gh"}},
+		{"💡💡💡", 4, []string{"💡💡", "💡"}},
+		{"\x1b[34mabcd\x1b[0m", 2, []string{"\x1b[34mab", "cd\x1b[0m"}},
+		{"abcdef", 0, []string{"abcdef"}},
+	}
+	for _, tc := range testCases {
+		if got := breakLine(tc.line, tc.width); strings.Join(got, "|") != strings.Join(tc.expected, "|") {
+			t.Errorf("breakLine(%q, %d) = %q, expected %q", tc.line, tc.width, got, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package renderer
+
+import (
+	"strings"
+
+	"github.com/zmtcreative/gm-alert-callouts/internal/constants"
+	gast "github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// TextLabelFormat selects how the label line of a callout is written in plain-text output.
+type TextLabelFormat int
+
+const (
+	// TextLabelKind writes the upper-cased kind followed by the custom title, e.g. "NOTE: Read this".
+	TextLabelKind TextLabelFormat = iota
+	// TextLabelTitle writes the custom title, or the title-cased kind when there is none, e.g. "Note".
+	TextLabelTitle
+)
+
+// TextConfig holds the options for the plain-text renderer.
+type TextConfig struct {
+	Aliases        map[string]string // Alias to primary kind map, used to resolve aliases to emoji
+	GFMKinds       map[string]string // GFM kind per kind, used when the map names neither the kind nor its primary
+	FoldingEnabled bool              // Whether folding functionality is enabled
+	AllowNOICON    bool              // Whether a 'noicon-' prefix suppresses the emoji
+	Label          TextLabelFormat   // How the label line is written
+	Emoji          bool              // Whether the label is prefixed with the kind's emoji
+	EmojiMap       map[string]string // Emoji per kind (constants.KIND_EMOJI when nil)
+	Indent         int               // Number of spaces the body is indented by
+	Width          int               // Column to wrap paragraphs at (0 disables wrapping)
+}
+
+// AlertsTextRenderer renders the alert nodes as plain text for use in a plain-text goldmark renderer.
+// A callout is written as its label line followed by the indented body text.
+type AlertsTextRenderer struct {
+	TextConfig
+	titleCaser cases.Caser
+}
+
+// NewAlertsTextRenderer returns a plain-text renderer for the Alerts, AlertsHeader and AlertsBody nodes.
+func NewAlertsTextRenderer(config TextConfig) renderer.NodeRenderer {
+	return newAlertsTextRenderer(config, DetectLanguageTag())
+}
+
+// newAlertsTextRenderer is an unexported constructor that allows injecting a language tag for tests.
+func newAlertsTextRenderer(config TextConfig, tag language.Tag) *AlertsTextRenderer {
+	return &AlertsTextRenderer{
+		TextConfig: config,
+		titleCaser: cases.Title(tag, cases.Compact),
+	}
+}
+
+func (r *AlertsTextRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(constants.KindAlerts, r.renderAlerts)
+	reg.Register(constants.KindAlertsHeader, r.renderAlertsHeader)
+	reg.Register(constants.KindAlertsBody, r.renderAlertsBody)
+}
+
+// renderAlerts writes the whole callout itself so the body can be indented and wrapped.
+func (r *AlertsTextRenderer) renderAlerts(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	if !entering {
+		return gast.WalkContinue, nil
+	}
+	writeLines(w, r.alertLines(node, source, r.Width))
+	if node.NextSibling() != nil {
+		w.WriteString("\n")
+	}
+	return gast.WalkSkipChildren, nil
+}
+
+func (r *AlertsTextRenderer) renderAlertsHeader(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	if entering {
+		w.WriteString(r.label(node, source))
+		w.WriteString("\n")
+	}
+	return gast.WalkSkipChildren, nil
+}
+
+func (r *AlertsTextRenderer) renderAlertsBody(w util.BufWriter, source []byte, node gast.Node, entering bool) (gast.WalkStatus, error) {
+	if entering {
+		writeLines(w, r.bodyLines(node, source, r.Width))
+	}
+	return gast.WalkSkipChildren, nil
+}
+
+// alertLines renders an Alerts node, including any nested callouts, into lines no wider than width.
+func (r *AlertsTextRenderer) alertLines(node gast.Node, source []byte, width int) []string {
+	lines := []string{r.label(node, source)}
+	if body := alertBody(node); body != nil {
+		lines = append(lines, r.bodyLines(body, source, width)...)
+	}
+	return lines
+}
+
+func (r *AlertsTextRenderer) bodyLines(body gast.Node, source []byte, width int) []string {
+	pad := strings.Repeat(" ", r.Indent)
+	return prefixLines(blockLines(body, source, width-r.Indent, blockFormat{Alert: r.alertLines}), pad, pad)
+}
+
+// label builds the first line of a callout according to the configured label format.
+func (r *AlertsTextRenderer) label(node gast.Node, source []byte) string {
+	attrs := readAlertAttributes(node)
+	title := alertTitle(node, source)
+
+	var label string
+	switch r.Label {
+	case TextLabelTitle:
+		label = title
+		if label == "" {
+			label = r.titleCaser.String(attrs.Kind)
+		}
+	default:
+		label = strings.ToUpper(attrs.Kind)
+		if title != "" {
+			label += ": " + title
+		}
+	}
+
+	if r.Emoji && !(r.AllowNOICON && attrs.NoIcon) {
+		if emoji := r.emoji(attrs.Kind); emoji != "" {
+			label = emoji + " " + label
+		}
+	}
+	return label
+}
+
+func (r *AlertsTextRenderer) emoji(kind string) string {
+	emojiMap := r.EmojiMap
+	if emojiMap == nil {
+		emojiMap = constants.KIND_EMOJI
+	}
+	return emojiMap[resolveKind(kind, r.Aliases, r.GFMKinds, emojiMap)]
+}
+
+func writeLines(w util.BufWriter, lines []string) {
+	for _, line := range lines {
+		w.WriteString(line)
+		w.WriteString("\n")
+	}
+}
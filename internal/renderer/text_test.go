@@ -0,0 +1,202 @@
+package renderer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	alertParser "github.com/zmtcreative/gm-alert-callouts/internal/parser"
+	gast "github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+	"golang.org/x/text/language"
+)
+
+// parseAlertsDocument parses source with the alert parsers added to the default goldmark parsers.
+func parseAlertsDocument(t *testing.T, source string) gast.Node {
+	t.Helper()
+	p := parser.NewParser(
+		parser.WithBlockParsers(append(parser.DefaultBlockParsers(),
+			util.Prioritized(alertParser.NewAlertsParser(nil, true, true), 799),
+			util.Prioritized(alertParser.NewAlertsHeaderParser(), 799),
+		)...),
+		parser.WithInlineParsers(parser.DefaultInlineParsers()...),
+		parser.WithParagraphTransformers(parser.DefaultParagraphTransformers()...),
+	)
+	return p.Parse(text.NewReader([]byte(source)))
+}
+
+// renderAlertsDocument parses source and renders it with only nr registered, so the output
+// contains nothing but what nr writes for the alert nodes.
+func renderAlertsDocument(t *testing.T, nr renderer.NodeRenderer, source string) string {
+	t.Helper()
+	doc := parseAlertsDocument(t, source)
+	var buf bytes.Buffer
+	r := renderer.NewRenderer(renderer.WithNodeRenderers(util.Prioritized(nr, 0)))
+	if err := r.Render(&buf, []byte(source), doc); err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	return buf.String()
+}
+
+func TestAlertsTextRendererRegisterFuncs(t *testing.T) {
+	r := NewAlertsTextRenderer(TextConfig{})
+
+	registrations := make(map[gast.NodeKind]renderer.NodeRendererFunc)
+	r.RegisterFuncs(&mockNodeRendererFuncRegisterer{registrations: registrations})
+
+	if len(registrations) != 3 {
+		t.Errorf("Expected 3 registrations, got %d", len(registrations))
+	}
+}
+
+func TestAlertsTextRendererLabels(t *testing.T) {
+	aliases := map[string]string{"info": "note"}
+
+	testCases := []struct {
+		name     string
+		config   TextConfig
+		input    string
+		expected string
+	}{
+		{
+			name:     "Kind label without title",
+			config:   TextConfig{Indent: 4},
+			input:    "> [!NOTE]\n> Body text",
+			expected: "NOTE\n    Body text\n",
+		},
+		{
+			name:     "Kind label with title",
+			config:   TextConfig{Indent: 4},
+			input:    "> [!note] Read *this*\n> Body text",
+			expected: "NOTE: Read this\n    Body text\n",
+		},
+		{
+			name:     "Title label falls back to title-cased kind",
+			config:   TextConfig{Label: TextLabelTitle, Indent: 2},
+			input:    "> [!warning]\n> Body text",
+			expected: "Warning\n  Body text\n",
+		},
+		{
+			name:     "Emoji prefix",
+			config:   TextConfig{Emoji: true, Indent: 4},
+			input:    "> [!tip]\n> Body text",
+			expected: "💡 TIP\n    Body text\n",
+		},
+		{
+			name:     "Emoji prefix resolves alias through the icon map",
+			config:   TextConfig{Aliases: aliases, Emoji: true, EmojiMap: map[string]string{"note": "N"}, Indent: 4},
+			input:    "> [!info]\n> Body text",
+			expected: "N INFO\n    Body text\n",
+		},
+		{
+			name:     "NOICON suppresses emoji",
+			config:   TextConfig{Emoji: true, AllowNOICON: true, Indent: 4},
+			input:    "> [!noicon-tip]\n> Body text",
+			expected: "TIP\n    Body text\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newAlertsTextRenderer(tc.config, language.English)
+			result := renderAlertsDocument(t, r, tc.input)
+			if result != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestAlertsTextRendererBody(t *testing.T) {
+	t.Run("Wraps paragraphs to width", func(t *testing.T) {
+		r := newAlertsTextRenderer(TextConfig{Indent: 2, Width: 20}, language.English)
+		result := renderAlertsDocument(t, r, "> [!note]\n> one two three four five six seven eight")
+		expected := "NOTE\n  one two three four\n  five six seven\n  eight\n"
+		if result != expected {
+			t.Errorf("Expected %q, got %q", expected, result)
+		}
+	})
+
+	t.Run("Lists, code and paragraphs", func(t *testing.T) {
+		r := newAlertsTextRenderer(TextConfig{Indent: 2}, language.English)
+		input := "> [!note]\n> First\n>\n> - a\n> - b\n>\n> ```\n> code  line\n> ```"
+		result := renderAlertsDocument(t, r, input)
+		expected := "NOTE\n  First\n\n  - a\n  - b\n\n  code  line\n"
+		if result != expected {
+			t.Errorf("Expected %q, got %q", expected, result)
+		}
+	})
+
+	t.Run("Nested callouts are indented further", func(t *testing.T) {
+		r := newAlertsTextRenderer(TextConfig{Indent: 2}, language.English)
+		input := "> [!note]\n> Outer\n>\n> > [!tip] Inner\n> > Nested body"
+		result := renderAlertsDocument(t, r, input)
+		expected := "NOTE\n  Outer\n\n  TIP: Inner\n    Nested body\n"
+		if result != expected {
+			t.Errorf("Expected %q, got %q", expected, result)
+		}
+	})
+
+	t.Run("Consecutive callouts are separated by a blank line", func(t *testing.T) {
+		r := newAlertsTextRenderer(TextConfig{Indent: 2}, language.English)
+		result := renderAlertsDocument(t, r, "> [!note]\n> A\n\n> [!tip]\n> B")
+		if !strings.Contains(result, "  A\n\nTIP\n") {
+			t.Errorf("Expected a blank line between callouts, got %q", result)
+		}
+	})
+}
+
+func TestWrapText(t *testing.T) {
+	testCases := []struct {
+		text     string
+		width    int
+		expected []string
+	}{
+		{"a b c", 0, []string{"a b c"}},
+		{"aaa bbb ccc", 7, []string{"aaa bbb", "ccc"}},
+		{"averyveryverylongword b", 5, []string{"averyveryverylongword", "b"}},
+		{"line one\nline two", 0, []string{"line one", "line two"}},
+	}
+
+	for _, tc := range testCases {
+		result := wrapText(tc.text, tc.width)
+		if strings.Join(result, "|") != strings.Join(tc.expected, "|") {
+			t.Errorf("wrapText(%q, %d) = %q, expected %q", tc.text, tc.width, result, tc.expected)
+		}
+	}
+}
+
+func TestResolveKind(t *testing.T) {
+	aliases := map[string]string{"info": "note", "hint": "tip"}
+	known := map[string]string{"note": "N"}
+
+	if got := resolveKind("note", aliases, nil, known); got != "note" {
+		t.Errorf("Expected note, got %q", got)
+	}
+	if got := resolveKind("info", aliases, nil, known); got != "note" {
+		t.Errorf("Expected alias info to resolve to note, got %q", got)
+	}
+	if got := resolveKind("custom", aliases, nil, known); got != "" {
+		t.Errorf("Expected no match for custom, got %q", got)
+	}
+	if got := resolveKind("hint", aliases, nil, known); got != "" {
+		t.Errorf("Expected no match for an alias of an unknown kind, got %q", got)
+	}
+	gfmKinds := map[string]string{"custom": "note", "info": "caution"}
+	if got := resolveKind("custom", aliases, gfmKinds, known); got != "note" {
+		t.Errorf("Expected custom to fall back to its GFM kind, got %q", got)
+	}
+	if got := resolveKind("info", aliases, gfmKinds, map[string]string{"note": "N", "caution": "C"}); got != "note" {
+		t.Errorf("Expected the primary kind to win over the GFM kind, got %q", got)
+	}
+}
+
+func TestSortedKeys(t *testing.T) {
+	keys := sortedKeys(map[string]int{"warning": 1, "caution": 2, "note": 3})
+	if strings.Join(keys, ",") != "caution,note,warning" {
+		t.Errorf("Expected sorted keys, got %v", keys)
+	}
+}
@@ -0,0 +1,75 @@
+package utilities
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Severity tells how serious a diagnostic is.
+type Severity int
+
+const (
+	// SeverityWarning marks a definition that was skipped or lost to another definition.
+	SeverityWarning Severity = iota
+	// SeverityInfo marks an expected override, such as a later file replacing a kind.
+	SeverityInfo
+)
+
+func (s Severity) String() string {
+	if s == SeverityInfo {
+		return "info"
+	}
+	return "warning"
+}
+
+// Position locates a line of an icon file. Line and Column are 1-based; File is empty for icon
+// data that does not come from a file.
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+func (p Position) String() string {
+	if p.File == "" {
+		return fmt.Sprintf("line %d", p.Line)
+	}
+	return fmt.Sprintf("%s:%d", p.File, p.Line)
+}
+
+// Diagnostic reports a line of icon data that was skipped or needs attention.
+type Diagnostic struct {
+	Position
+	Kind     string // Kind the line defines, when known
+	Severity Severity
+	Reason   string
+}
+
+// String formats the diagnostic as "file:line:column: severity: reason".
+func (d Diagnostic) String() string {
+	file := d.File
+	if file == "" {
+		file = "<icons>"
+	}
+	return fmt.Sprintf("%s:%d:%d: %s: %s", file, d.Line, d.Column, d.Severity, d.Reason)
+}
+
+// SortDiagnostics sorts diagnostics by file, line and column, keeping the order of diagnostics
+// on the same position.
+func SortDiagnostics(diagnostics []Diagnostic) {
+	slices.SortStableFunc(diagnostics, func(a, b Diagnostic) int {
+		if c := strings.Compare(a.File, b.File); c != 0 {
+			return c
+		}
+		if a.Line != b.Line {
+			return a.Line - b.Line
+		}
+		return a.Column - b.Column
+	})
+}
+
+// HasWarnings reports whether any of the diagnostics is a warning.
+func HasWarnings(diagnostics []Diagnostic) bool {
+	return slices.ContainsFunc(diagnostics, func(d Diagnostic) bool { return d.Severity == SeverityWarning })
+}
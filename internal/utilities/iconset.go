@@ -0,0 +1,488 @@
+package utilities
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Fold states accepted by the 'fold' field of an icon definition.
+const (
+	FoldNone   = "none"
+	FoldOpen   = "open"
+	FoldClosed = "closed"
+)
+
+// GFMKinds are the five alert kinds GitHub renders, the only valid values of the 'gfm' field.
+var GFMKinds = []string{"note", "tip", "important", "warning", "caution"}
+
+var (
+	// fieldLineRegex matches optional per-kind field definitions (kind.field=value).
+	fieldLineRegex = regexp.MustCompile(`^(?P<kind>\p{L}[\p{L}\p{N}_-]*)\.(?P<field>[a-z][a-z-]*)\s*=\s*(?P<value>.*)$`)
+	hexColorRegex  = regexp.MustCompile(`^#?([0-9a-fA-F]{6})$`)
+)
+
+// IconDefinition holds everything an icon set says about a single kind.
+// All fields except Kind and Icon are optional and empty when the icon set does not set them.
+type IconDefinition struct {
+	Kind    string // Lower-cased kind
+	Icon    string // Icon markup (typically SVG)
+	Primary string // Primary kind when Kind is an alias, empty for primary kinds
+	Color   string // Accent color as a six-digit upper-case hex value without '#'
+	Title   string // Default display title, used when the markdown gives none
+	Label   string // Accessible label for the icon
+	Fold    string // Default fold state: FoldNone, FoldOpen or FoldClosed
+	GFMKind string // GFM kind to fall back to where only the five GFM kinds are supported
+}
+
+// IconSet is the parsed form of an icon definition file.
+// Aliases inherit the optional fields of their primary kind unless they set their own.
+type IconSet struct {
+	definitions map[string]*IconDefinition
+	order       []string
+	sources     map[string]int // Index of the entry defining each kind
+	entries     []iconEntry    // Primary and alias lines in layer and line order
+	fields      []fieldEntry   // Field lines in layer and line order
+	comments    []commentEntry // Comment lines in layer and line order
+}
+
+// iconEntry is a primary (kind|icon) or alias (alias->target) line of an icon file.
+type iconEntry struct {
+	kind         string
+	icon         string // Icon of a primary entry
+	target       string // Direct target of an alias entry, which may itself be an alias
+	alias        bool
+	layer        int // Index of the file the entry comes from; later files win
+	pos          Position
+	targetColumn int
+}
+
+// commentEntry is a comment line (# text) of an icon file.
+type commentEntry struct {
+	text   string
+	before int // Index of the first entry after the comment
+	pos    Position
+}
+
+// fieldEntry is an optional field line (kind.field=value) of an icon file.
+type fieldEntry struct {
+	kind  string
+	field string
+	value string
+	pos   Position
+}
+
+// ParseIconSet parses icon data in the .icons format:
+//
+//	# comment
+//	note|<svg>...</svg>
+//	info->note
+//	note.color=#0969DA
+//	note.title=Note
+//	note.label=Information
+//	note.fold=open
+//	note.gfm=note
+//
+// Lines that cannot be parsed are skipped, as are fields of kinds that are not defined and fields
+// with invalid values, so the result always matches what CreateIconsMap would load.
+// Use ParseIconSetDiagnostics to find out what was skipped.
+func ParseIconSet(icondata string) *IconSet {
+	set, _ := ParseIconSetDiagnostics(icondata, "")
+	return set
+}
+
+// ParseIconSetDiagnostics parses icon data like ParseIconSet and also reports alias chains that
+// cannot be resolved and definitions that collide. The file name is only used in the diagnostics.
+//
+// Aliases may point at other aliases, in any line order; each alias resolves to the primary kind
+// at the end of its chain. Within a file, a primary kind wins over an alias of the same name and a
+// later definition wins over an earlier one of the same type (see MergeIconSets for several files).
+func ParseIconSetDiagnostics(icondata string, file string) (*IconSet, []Diagnostic) {
+	set := &IconSet{}
+	var diagnostics []Diagnostic
+	for i, line := range strings.Split(icondata, "\n") {
+		diagnostics = append(diagnostics, set.scanLine(strings.TrimSuffix(line, "\r"), Position{File: file, Line: i + 1})...)
+	}
+	diagnostics = append(diagnostics, set.build()...)
+	SortDiagnostics(diagnostics)
+	return set, diagnostics
+}
+
+// MergeIconSets combines icon sets into one, as if their files were read one after the other.
+// A kind defined in a later set replaces the earlier definition, whether either is a primary
+// kind or an alias, and aliases may point at kinds of any of the sets. The diagnostics cover
+// the resolution of the combined set, including every kind a later set overrides.
+func MergeIconSets(sets ...*IconSet) (*IconSet, []Diagnostic) {
+	merged := &IconSet{}
+	base, offset := 0, 0
+	for _, set := range sets {
+		next := base
+		for _, e := range set.entries {
+			e.layer += base
+			next = max(next, e.layer+1)
+			merged.entries = append(merged.entries, e)
+		}
+		merged.fields = append(merged.fields, set.fields...)
+		for _, c := range set.comments {
+			c.before += offset
+			merged.comments = append(merged.comments, c)
+		}
+		offset += len(set.entries)
+		base = next
+	}
+	return merged, merged.build()
+}
+
+// scanLine records the entry on a single line of icon data, reporting lines that are skipped
+// and definitions that look wrong.
+func (s *IconSet) scanLine(line string, pos Position) []Diagnostic {
+	trimmed := strings.TrimSpace(line)
+	indent := len(line) - len(strings.TrimLeft(line, " \t"))
+	pos.Column = indent + 1
+
+	// Skip empty lines, and keep comments for Groups.
+	if trimmed == "" {
+		return nil
+	}
+	if strings.HasPrefix(trimmed, "#") {
+		s.comments = append(s.comments, commentEntry{text: strings.TrimSpace(trimmed[1:]), before: len(s.entries), pos: pos})
+		return nil
+	}
+
+	warn := func(column int, kind string, reason string) []Diagnostic {
+		pos.Column = column
+		return []Diagnostic{{Position: pos, Kind: kind, Severity: SeverityWarning, Reason: reason}}
+	}
+
+	// Optional fields (kind.field=value).
+	if match := FindNamedMatches(fieldLineRegex, trimmed); len(match) > 0 {
+		s.fields = append(s.fields, fieldEntry{
+			kind:  strings.ToLower(match["kind"]),
+			field: match["field"],
+			value: unquote(strings.TrimSpace(match["value"])),
+			pos:   pos,
+		})
+		return nil
+	}
+
+	// Alias definitions (alias->target). A '|' before the arrow makes the line a core definition,
+	// so icons can contain "->".
+	bar := strings.Index(line, "|")
+	if arrow := strings.Index(line, "->"); arrow >= 0 && (bar < 0 || arrow < bar) {
+		alias := strings.ToLower(strings.TrimSpace(line[:arrow]))
+		rest := line[arrow+2:]
+		target := strings.ToLower(strings.TrimSpace(rest))
+		targetColumn := arrow + 2 + len(rest) - len(strings.TrimLeft(rest, " \t")) + 1
+		if problem := keyProblem(alias); problem != "" {
+			return warn(pos.Column, alias, "alias skipped: "+problem)
+		}
+		if problem := keyProblem(target); problem != "" {
+			return warn(targetColumn, alias, "alias skipped: "+problem)
+		}
+		s.entries = append(s.entries, iconEntry{kind: alias, target: target, alias: true, pos: pos, targetColumn: targetColumn})
+		return nil
+	}
+
+	// Core icon definitions (key|svg).
+	if bar < 0 {
+		return warn(pos.Column, "", "line skipped: expected 'kind|icon', 'alias->kind', 'kind.field=value' or a '#' comment")
+	}
+	key := strings.ToLower(strings.TrimSpace(line[:bar]))
+	svg := strings.TrimSpace(line[bar+1:])
+	if problem := keyProblem(key); problem != "" {
+		return warn(pos.Column, key, "icon skipped: "+problem)
+	}
+	s.entries = append(s.entries, iconEntry{kind: key, icon: svg, pos: pos})
+	if svg == "" {
+		return warn(bar+2, key, fmt.Sprintf("kind %q has an empty icon", key))
+	}
+	return nil
+}
+
+// build resolves the entries and fields into definitions, reporting collisions and aliases that
+// cannot be resolved.
+func (s *IconSet) build() []Diagnostic {
+	s.definitions = make(map[string]*IconDefinition)
+	s.sources = make(map[string]int)
+	s.order = nil
+
+	// Rank the entries of every kind, best first: later files win, then primary kinds over
+	// aliases, then later lines.
+	candidates := make(map[string][]*iconEntry)
+	for i := range s.entries {
+		e := &s.entries[i]
+		candidates[e.kind] = append([]*iconEntry{e}, candidates[e.kind]...)
+	}
+	for _, list := range candidates {
+		slices.SortStableFunc(list, func(a, b *iconEntry) int {
+			if a.layer != b.layer {
+				return b.layer - a.layer
+			}
+			if a.alias != b.alias && !a.alias {
+				return -1
+			} else if a.alias != b.alias {
+				return 1
+			}
+			return 0
+		})
+	}
+
+	// Resolve the winning aliases. An alias that cannot be resolved drops out, so the next
+	// entry of its kind (if any) takes over, which may in turn fix or break other chains.
+	roots := make(map[*iconEntry]*iconEntry)
+	broken := make(map[*iconEntry]Diagnostic)
+	cyclic := make(map[string]bool)
+	for changed := true; changed; {
+		changed = false
+		winners := make(map[string]*iconEntry)
+		for kind, list := range candidates {
+			if len(list) > 0 {
+				winners[kind] = list[0]
+			}
+		}
+		clear(roots)
+		for i := range s.entries {
+			e := &s.entries[i]
+			if winners[e.kind] != e || !e.alias {
+				continue
+			}
+			root, diagnostic, cycle := resolveAlias(e, winners, cyclic)
+			for _, kind := range cycle {
+				broken[winners[kind]] = cycleDiagnostic(winners[kind], cycle)
+				candidates[kind] = candidates[kind][1:]
+				cyclic[kind] = true
+			}
+			if root == nil && cycle == nil {
+				broken[e] = diagnostic
+				candidates[e.kind] = candidates[e.kind][1:]
+			}
+			if root == nil {
+				changed = true
+				break
+			}
+			roots[e] = root
+		}
+	}
+
+	// Primary kinds first, then the aliases, each in the order they appear.
+	var diagnostics []Diagnostic
+	for i := range s.entries {
+		e := &s.entries[i]
+		if d, ok := broken[e]; ok {
+			diagnostics = append(diagnostics, d)
+		} else if winner := candidates[e.kind][0]; winner != e {
+			diagnostics = append(diagnostics, collision(winner, e))
+		} else if !e.alias {
+			s.add(&IconDefinition{Kind: e.kind, Icon: e.icon})
+			s.sources[e.kind] = i
+		}
+	}
+	for i := range s.entries {
+		e := &s.entries[i]
+		if root, ok := roots[e]; ok {
+			s.add(&IconDefinition{Kind: e.kind, Icon: root.icon, Primary: root.kind})
+			s.sources[e.kind] = i
+		}
+	}
+
+	// Apply the optional fields.
+	for _, f := range s.fields {
+		def, exists := s.definitions[f.kind]
+		if !exists {
+			diagnostics = append(diagnostics, Diagnostic{Position: f.pos, Kind: f.kind, Severity: SeverityWarning,
+				Reason: fmt.Sprintf("field %q skipped: kind %q is not defined", f.field, f.kind)})
+		} else if problem := setField(def, f.field, f.value); problem != "" {
+			diagnostics = append(diagnostics, Diagnostic{Position: f.pos, Kind: f.kind, Severity: SeverityWarning,
+				Reason: fmt.Sprintf("field %q skipped: %s", f.field, problem)})
+		}
+	}
+
+	return diagnostics
+}
+
+// collision describes the entry that loses to the winning entry of the same kind.
+func collision(winner *iconEntry, loser *iconEntry) Diagnostic {
+	d := Diagnostic{Position: loser.pos, Kind: loser.kind, Severity: SeverityWarning}
+	switch {
+	case winner.layer != loser.layer:
+		d.Severity = SeverityInfo
+		d.Reason = fmt.Sprintf("%q is overridden by the definition at %s", loser.kind, winner.pos)
+	case !winner.alias && loser.alias:
+		d.Reason = fmt.Sprintf("alias %q collides with the primary kind defined at %s; the primary kind wins", loser.kind, winner.pos)
+	case winner.alias && loser.alias:
+		d.Reason = fmt.Sprintf("alias %q is redefined at %s; the later alias wins", loser.kind, winner.pos)
+	default:
+		d.Reason = fmt.Sprintf("kind %q is redefined at %s; the later definition wins", loser.kind, winner.pos)
+	}
+	return d
+}
+
+// resolveAlias follows the chain of alias e to its primary kind. When the chain ends at an
+// unknown kind or runs in a cycle it returns nil and a diagnostic, and for a cycle also the
+// aliases that form it. Kinds in cyclic already dropped out because of an earlier cycle.
+func resolveAlias(e *iconEntry, winners map[string]*iconEntry, cyclic map[string]bool) (*iconEntry, Diagnostic, []string) {
+	chain := []string{e.kind}
+	for target := e.target; ; {
+		w, exists := winners[target]
+		if !exists {
+			pos := e.pos
+			if len(chain) == 1 {
+				pos.Column = e.targetColumn
+			}
+			reason := fmt.Sprintf("alias %q refers to unknown kind %q", e.kind, target)
+			if cyclic[target] {
+				reason = fmt.Sprintf("alias %q refers to %q, which is part of a cycle", e.kind, target)
+			}
+			return nil, Diagnostic{Position: pos, Kind: e.kind, Severity: SeverityWarning, Reason: reason}, nil
+		}
+		if !w.alias {
+			return w, Diagnostic{}, nil
+		}
+		if i := slices.Index(chain, target); i >= 0 {
+			return nil, Diagnostic{}, chain[i:]
+		}
+		chain = append(chain, target)
+		target = w.target
+	}
+}
+
+// cycleDiagnostic describes alias e as part of cycle, starting the cycle at e.
+func cycleDiagnostic(e *iconEntry, cycle []string) Diagnostic {
+	i := slices.Index(cycle, e.kind)
+	path := append(append(slices.Clone(cycle[i:]), cycle[:i]...), e.kind)
+	return Diagnostic{Position: e.pos, Kind: e.kind, Severity: SeverityWarning,
+		Reason: fmt.Sprintf("alias %q is part of a cycle: %s", e.kind, strings.Join(path, " -> "))}
+}
+
+// clone returns a copy of the lines of the icon set that can be changed and built again.
+func (s *IconSet) clone() *IconSet {
+	return &IconSet{entries: slices.Clone(s.entries), fields: slices.Clone(s.fields), comments: slices.Clone(s.comments)}
+}
+
+// add records a new definition, keeping the order in which kinds are added.
+func (s *IconSet) add(def *IconDefinition) {
+	s.definitions[def.Kind] = def
+	s.order = append(s.order, def.Kind)
+}
+
+// setField sets a single optional field. It returns why the field was ignored when the field is
+// unknown or the value is invalid, and an empty string otherwise.
+func setField(def *IconDefinition, field string, value string) string {
+	switch field {
+	case "color":
+		m := hexColorRegex.FindStringSubmatch(value)
+		if m == nil {
+			return fmt.Sprintf("%q is not a six-digit hex color", value)
+		}
+		def.Color = strings.ToUpper(m[1])
+	case "title":
+		def.Title = value
+	case "label":
+		def.Label = value
+	case "fold":
+		if value = strings.ToLower(value); value != FoldNone && value != FoldOpen && value != FoldClosed {
+			return fmt.Sprintf("%q is not one of none, open or closed", value)
+		}
+		def.Fold = value
+	case "gfm":
+		if value = strings.ToLower(value); !slices.Contains(GFMKinds, value) {
+			return fmt.Sprintf("%q is not one of %s", value, strings.Join(GFMKinds, ", "))
+		}
+		def.GFMKind = value
+	default:
+		return "unknown field"
+	}
+	return ""
+}
+
+// unquote removes a pair of surrounding double quotes from value.
+func unquote(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// Kinds returns every kind of the icon set, primaries and aliases, in the order they first appear.
+func (s *IconSet) Kinds() []string {
+	return slices.Clone(s.order)
+}
+
+// Definition returns the definition of kind, with the optional fields an alias does not set
+// inherited from its primary kind.
+func (s *IconSet) Definition(kind string) (IconDefinition, bool) {
+	def, ok := s.definitions[strings.ToLower(kind)]
+	if !ok {
+		return IconDefinition{}, false
+	}
+	result := *def
+	if primary, ok := s.definitions[def.Primary]; ok {
+		result.Color = firstNonEmpty(result.Color, primary.Color)
+		result.Title = firstNonEmpty(result.Title, primary.Title)
+		result.Label = firstNonEmpty(result.Label, primary.Label)
+		result.Fold = firstNonEmpty(result.Fold, primary.Fold)
+		result.GFMKind = firstNonEmpty(result.GFMKind, primary.GFMKind)
+	}
+	return result, true
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// project returns a map of every kind whose (inherited) field value is not empty.
+func (s *IconSet) project(field func(IconDefinition) string) map[string]string {
+	result := make(map[string]string)
+	for _, kind := range s.order {
+		def, _ := s.Definition(kind)
+		if value := field(def); value != "" {
+			result[kind] = value
+		}
+	}
+	return result
+}
+
+// Icons returns the map of kinds to icons, as loaded by CreateIconsMap.
+func (s *IconSet) Icons() map[string]string {
+	result := make(map[string]string, len(s.order))
+	for _, kind := range s.order {
+		result[kind] = s.definitions[kind].Icon
+	}
+	return result
+}
+
+// Aliases returns the map of aliases to their primary kind, as loaded by CreateIconAliasesMap.
+func (s *IconSet) Aliases() map[string]string {
+	return s.project(func(d IconDefinition) string { return d.Primary })
+}
+
+// Colors returns the accent color of every kind that has one.
+func (s *IconSet) Colors() map[string]string {
+	return s.project(func(d IconDefinition) string { return d.Color })
+}
+
+// Titles returns the default display title of every kind that has one.
+func (s *IconSet) Titles() map[string]string {
+	return s.project(func(d IconDefinition) string { return d.Title })
+}
+
+// Labels returns the accessible label of every kind that has one.
+func (s *IconSet) Labels() map[string]string {
+	return s.project(func(d IconDefinition) string { return d.Label })
+}
+
+// FoldStates returns the default fold state of every kind that has one.
+func (s *IconSet) FoldStates() map[string]string {
+	return s.project(func(d IconDefinition) string { return d.Fold })
+}
+
+// GFMKinds returns the GFM fallback kind of every kind that has one.
+func (s *IconSet) GFMKinds() map[string]string {
+	return s.project(func(d IconDefinition) string { return d.GFMKind })
+}
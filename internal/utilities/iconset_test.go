@@ -0,0 +1,186 @@
+package utilities
+
+import (
+	"reflect"
+	"testing"
+)
+
+const iconSetTestData = `# Icons
+note|<svg>note</svg>
+warning|<svg>warning</svg>
+info->note
+caution->warning
+
+# Fields
+note.color=#0969da
+note.title="Heads up"
+note.label=Information
+note.fold=Open
+note.gfm=note
+info.title=Info
+warning.color=nothex
+warning.fold=sideways
+warning.gfm=danger
+warning.unknown=value
+missing.title=Nobody
+caution.title=A -> B | C`
+
+func TestParseIconSet(t *testing.T) {
+	set := ParseIconSet(iconSetTestData)
+
+	t.Run("Kinds keep file order", func(t *testing.T) {
+		expected := []string{"note", "warning", "info", "caution"}
+		if !reflect.DeepEqual(set.Kinds(), expected) {
+			t.Errorf("Expected %v, got %v", expected, set.Kinds())
+		}
+	})
+
+	t.Run("Fields of a primary kind", func(t *testing.T) {
+		def, ok := set.Definition("NOTE")
+		if !ok {
+			t.Fatal("Expected a definition for 'note'")
+		}
+		expected := IconDefinition{Kind: "note", Icon: "<svg>note</svg>", Color: "0969DA", Title: "Heads up", Label: "Information", Fold: FoldOpen, GFMKind: "note"}
+		if def != expected {
+			t.Errorf("Expected %+v, got %+v", expected, def)
+		}
+	})
+
+	t.Run("Aliases inherit unset fields", func(t *testing.T) {
+		def, _ := set.Definition("info")
+		if def.Primary != "note" || def.Title != "Info" || def.Color != "0969DA" || def.Fold != FoldOpen {
+			t.Errorf("Unexpected alias definition: %+v", def)
+		}
+	})
+
+	t.Run("Invalid values and unknown fields are ignored", func(t *testing.T) {
+		def, _ := set.Definition("warning")
+		if def.Color != "" || def.Fold != "" || def.GFMKind != "" {
+			t.Errorf("Expected invalid fields to be ignored, got %+v", def)
+		}
+		if _, ok := set.Definition("missing"); ok {
+			t.Error("Expected no definition for a kind that only has fields")
+		}
+		if def, _ := set.Definition("caution"); def.Title != "A -> B | C" {
+			t.Errorf("Expected field values to keep '->' and '|', got %q", def.Title)
+		}
+	})
+
+	t.Run("Projections", func(t *testing.T) {
+		if !reflect.DeepEqual(set.Icons(), CreateIconsMap(iconSetTestData)) {
+			t.Errorf("Expected Icons() to match CreateIconsMap, got %v", set.Icons())
+		}
+		if !reflect.DeepEqual(set.Aliases(), map[string]string{"info": "note", "caution": "warning"}) {
+			t.Errorf("Unexpected aliases: %v", set.Aliases())
+		}
+		if !reflect.DeepEqual(set.Titles(), map[string]string{"note": "Heads up", "info": "Info", "caution": "A -> B | C"}) {
+			t.Errorf("Unexpected titles: %v", set.Titles())
+		}
+		if !reflect.DeepEqual(set.Colors(), map[string]string{"note": "0969DA", "info": "0969DA"}) {
+			t.Errorf("Unexpected colors: %v", set.Colors())
+		}
+		if len(set.Labels()) != 2 || len(set.FoldStates()) != 2 || len(set.GFMKinds()) != 2 {
+			t.Errorf("Unexpected labels, fold states or GFM kinds: %v %v %v", set.Labels(), set.FoldStates(), set.GFMKinds())
+		}
+	})
+
+	t.Run("Format without fields is unchanged", func(t *testing.T) {
+		data := "note|<svg>note</svg>\ninfo->note\n"
+		if !reflect.DeepEqual(ParseIconSet(data).Icons(), map[string]string{"note": "<svg>note</svg>", "info": "<svg>note</svg>"}) {
+			t.Error("Expected plain icon data to parse as before")
+		}
+	})
+}
+
+func TestParseIconSetAliasChains(t *testing.T) {
+	reasons := func(diagnostics []Diagnostic) []string {
+		var result []string
+		for _, d := range diagnostics {
+			result = append(result, d.String())
+		}
+		return result
+	}
+
+	t.Run("Chains resolve regardless of line order", func(t *testing.T) {
+		set, diagnostics := ParseIconSetDiagnostics("hint->tip2\ntip2->tip\ntip|<svg>tip</svg>\n", "")
+		if len(diagnostics) != 0 {
+			t.Errorf("Expected no diagnostics, got %v", reasons(diagnostics))
+		}
+		expected := map[string]string{"hint": "tip", "tip2": "tip"}
+		if !reflect.DeepEqual(set.Aliases(), expected) {
+			t.Errorf("Expected %v, got %v", expected, set.Aliases())
+		}
+		if set.Icons()["hint"] != "<svg>tip</svg>" {
+			t.Errorf("Expected hint to get the tip icon, got %q", set.Icons()["hint"])
+		}
+	})
+
+	t.Run("Cycles and unknown targets are reported", func(t *testing.T) {
+		set, diagnostics := ParseIconSetDiagnostics("note|<svg/>\na->b\nb->a\nself->self\nlost ->  missing\nc->a\n", "test.icons")
+		expected := []string{
+			`test.icons:2:1: warning: alias "a" is part of a cycle: a -> b -> a`,
+			`test.icons:3:1: warning: alias "b" is part of a cycle: b -> a -> b`,
+			`test.icons:4:1: warning: alias "self" is part of a cycle: self -> self`,
+			`test.icons:5:10: warning: alias "lost" refers to unknown kind "missing"`,
+			`test.icons:6:4: warning: alias "c" refers to "a", which is part of a cycle`,
+		}
+		if !reflect.DeepEqual(reasons(diagnostics), expected) {
+			t.Errorf("Expected %v, got %v", expected, reasons(diagnostics))
+		}
+		if !reflect.DeepEqual(set.Kinds(), []string{"note"}) {
+			t.Errorf("Expected only note, got %v", set.Kinds())
+		}
+	})
+
+	t.Run("Primary kinds win over aliases and later lines over earlier ones", func(t *testing.T) {
+		set, diagnostics := ParseIconSetDiagnostics("note->tip\nnote|<svg>note</svg>\ntip|<svg>a</svg>\ntip|<svg>b</svg>\ninfo->note\ninfo->tip\n", "")
+		expected := []string{
+			`<icons>:1:1: warning: alias "note" collides with the primary kind defined at line 2; the primary kind wins`,
+			`<icons>:3:1: warning: kind "tip" is redefined at line 4; the later definition wins`,
+			`<icons>:5:1: warning: alias "info" is redefined at line 6; the later alias wins`,
+		}
+		if !reflect.DeepEqual(reasons(diagnostics), expected) {
+			t.Errorf("Expected %v, got %v", expected, reasons(diagnostics))
+		}
+		icons := set.Icons()
+		if icons["note"] != "<svg>note</svg>" || icons["tip"] != "<svg>b</svg>" || icons["info"] != "<svg>b</svg>" {
+			t.Errorf("Unexpected icons: %v", icons)
+		}
+	})
+
+	t.Run("Broken aliases do not replace working ones", func(t *testing.T) {
+		set, diagnostics := ParseIconSetDiagnostics("note|<svg/>\ninfo->note\ninfo->missing\n", "")
+		if set.Aliases()["info"] != "note" || len(diagnostics) != 1 {
+			t.Errorf("Expected info to stay an alias of note with one diagnostic, got %v %v", set.Aliases(), reasons(diagnostics))
+		}
+	})
+}
+
+func TestMergeIconSets(t *testing.T) {
+	base, _ := ParseIconSetDiagnostics("note|<svg>note</svg>\ntip|<svg>tip</svg>\ninfo->note\nnote.title=Note\n", "base.icons")
+	brand, _ := ParseIconSetDiagnostics("info|<svg>info</svg>\ntip->note\nhint->info\n", "brand.icons")
+
+	set, diagnostics := MergeIconSets(base, brand)
+
+	expected := []string{
+		`base.icons:2:1: info: "tip" is overridden by the definition at brand.icons:2`,
+		`base.icons:3:1: info: "info" is overridden by the definition at brand.icons:1`,
+	}
+	var got []string
+	for _, d := range diagnostics {
+		got = append(got, d.String())
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	if !reflect.DeepEqual(set.Aliases(), map[string]string{"tip": "note", "hint": "info"}) {
+		t.Errorf("Unexpected aliases: %v", set.Aliases())
+	}
+	if set.Icons()["info"] != "<svg>info</svg>" || set.Titles()["tip"] != "Note" {
+		t.Errorf("Unexpected icons or titles: %v %v", set.Icons(), set.Titles())
+	}
+	if !reflect.DeepEqual(set.Kinds(), []string{"note", "info", "tip", "hint"}) {
+		t.Errorf("Unexpected kind order: %v", set.Kinds())
+	}
+}
@@ -0,0 +1,66 @@
+package utilities
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// IconManifestFile is the name of the optional manifest in an icon directory. It uses the .icons
+// format, typically for aliases (alias->kind) and fields (kind.field=value).
+const IconManifestFile = "manifest.icons"
+
+// ParseIconSetFS builds an icon set from the .svg files in dir of fsys. The file name without
+// its extension is the kind, and each file is collapsed to a single line with CollapseSVG.
+// The optional manifest (IconManifestFile) in the same directory is read after the icons, so its
+// aliases and fields can refer to them. Files that cannot be used are reported as diagnostics;
+// an error is only returned when dir or one of its files cannot be read.
+func ParseIconSetFS(fsys fs.FS, dir string) (*IconSet, []Diagnostic, error) {
+	files, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	set := &IconSet{}
+	var diagnostics []Diagnostic
+	for _, file := range files {
+		ext := path.Ext(file.Name())
+		if file.IsDir() || !strings.EqualFold(ext, ".svg") {
+			continue
+		}
+		name := path.Join(dir, file.Name())
+		pos := Position{File: name, Line: 1, Column: 1}
+		kind := strings.ToLower(strings.TrimSuffix(file.Name(), ext))
+		if problem := keyProblem(kind); problem != "" {
+			diagnostics = append(diagnostics, Diagnostic{Position: pos, Kind: kind, Severity: SeverityWarning, Reason: "icon skipped: " + problem})
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, nil, err
+		}
+		svg := CollapseSVG(string(data))
+		if !strings.HasPrefix(svg, "<svg") {
+			diagnostics = append(diagnostics, Diagnostic{Position: pos, Kind: kind, Severity: SeverityWarning,
+				Reason: fmt.Sprintf("icon skipped: %s does not contain an <svg> element", file.Name())})
+			continue
+		}
+		set.entries = append(set.entries, iconEntry{kind: kind, icon: svg, pos: pos})
+	}
+
+	manifest := path.Join(dir, IconManifestFile)
+	data, err := fs.ReadFile(fsys, manifest)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, err
+	}
+	for i, line := range strings.Split(string(data), "\n") {
+		diagnostics = append(diagnostics, set.scanLine(strings.TrimSuffix(line, "\r"), Position{File: manifest, Line: i + 1})...)
+	}
+
+	diagnostics = append(diagnostics, set.build()...)
+	SortDiagnostics(diagnostics)
+	return set, diagnostics, nil
+}
@@ -0,0 +1,63 @@
+package utilities
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestParseIconSetFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"icons/note.svg":       {Data: []byte("<?xml version=\"1.0\"?>\n<svg viewBox=\"0 0 24 24\">\n  <path d=\"M1 1\"/>\n</svg>\n")},
+		"icons/Warning.SVG":    {Data: []byte("<svg><path d=\"M2 2\"/></svg>")},
+		"icons/noicon-tip.svg": {Data: []byte("<svg/>")},
+		"icons/broken.svg":     {Data: []byte("not an icon")},
+		"icons/readme.txt":     {Data: []byte("ignored")},
+		"icons/nested/tip.svg": {Data: []byte("<svg/>")},
+		"icons/manifest.icons": {Data: []byte("# Aliases\ninfo->note\ncaution->warning\nnote.title=Heads up\nhint->tip\n")},
+	}
+
+	set, diagnostics, err := ParseIconSetFS(fsys, "icons")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedIcons := map[string]string{
+		"note":    `<svg viewBox="0 0 24 24"><path d="M1 1"/></svg>`,
+		"warning": `<svg><path d="M2 2"/></svg>`,
+		"info":    `<svg viewBox="0 0 24 24"><path d="M1 1"/></svg>`,
+		"caution": `<svg><path d="M2 2"/></svg>`,
+	}
+	if !reflect.DeepEqual(set.Icons(), expectedIcons) {
+		t.Errorf("Expected %v, got %v", expectedIcons, set.Icons())
+	}
+	if set.Titles()["info"] != "Heads up" {
+		t.Errorf("Expected manifest fields to apply, got %v", set.Titles())
+	}
+
+	var got []string
+	for _, d := range diagnostics {
+		got = append(got, d.String())
+	}
+	expected := []string{
+		`icons/broken.svg:1:1: warning: icon skipped: broken.svg does not contain an <svg> element`,
+		`icons/manifest.icons:5:7: warning: alias "hint" refers to unknown kind "tip"`,
+		`icons/noicon-tip.svg:1:1: warning: icon skipped: kind "noicon-tip" uses the reserved prefix "noicon-"`,
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	t.Run("Manifest is optional", func(t *testing.T) {
+		set, diagnostics, err := ParseIconSetFS(fstest.MapFS{"a.svg": {Data: []byte("<svg/>")}}, ".")
+		if err != nil || len(diagnostics) != 0 || set.Icons()["a"] != "<svg/>" {
+			t.Errorf("Unexpected result: %v %v %v", set.Icons(), diagnostics, err)
+		}
+	})
+
+	t.Run("Missing directory", func(t *testing.T) {
+		if _, _, err := ParseIconSetFS(fsys, "missing"); err == nil {
+			t.Error("Expected an error for a missing directory")
+		}
+	})
+}
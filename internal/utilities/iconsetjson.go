@@ -0,0 +1,161 @@
+package utilities
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// IconSetSchemaVersion is the version of the JSON form of icon sets. It is increased whenever a
+// field is renamed, removed or changes meaning; new optional fields do not change the version.
+const IconSetSchemaVersion = 1
+
+// IconSetDocument is the JSON form of an icon set.
+type IconSetDocument struct {
+	Version  int            `json:"version"`            // Schema version (IconSetSchemaVersion)
+	Kinds    []IconSetEntry `json:"kinds"`              // Primary kinds and aliases in file order
+	Comments [][]string     `json:"comments,omitempty"` // Comment blocks after the last kind
+}
+
+// IconSetEntry is the JSON form of a primary kind or alias. Exactly one of Icon and Alias is set.
+// The optional fields are those the kind sets itself; aliases inherit the others.
+type IconSetEntry struct {
+	Kind     string     `json:"kind"`               // Lower-cased kind
+	Icon     string     `json:"icon,omitempty"`     // Icon markup of a primary kind
+	Alias    string     `json:"alias,omitempty"`    // Direct target of an alias, which may itself be an alias
+	Color    string     `json:"color,omitempty"`    // Accent color as "#RRGGBB"
+	Title    string     `json:"title,omitempty"`    // Default display title
+	Label    string     `json:"label,omitempty"`    // Accessible label for the icon
+	Fold     string     `json:"fold,omitempty"`     // Default fold state: none, open or closed
+	GFM      string     `json:"gfm,omitempty"`      // GFM fallback kind
+	Comments [][]string `json:"comments,omitempty"` // Comment blocks before the kind, one slice per block
+}
+
+// Document returns the JSON form of the icon set, leaving out definitions that lost to others
+// and fields that were skipped, like WriteIcons.
+func (s *IconSet) Document() *IconSetDocument {
+	doc := &IconSetDocument{Version: IconSetSchemaVersion, Kinds: []IconSetEntry{}}
+	s.walk(func(blocks [][]string, e *iconEntry) {
+		if e == nil {
+			doc.Comments = blocks
+			return
+		}
+		def := s.definitions[e.kind]
+		entry := IconSetEntry{
+			Kind:     e.kind,
+			Color:    ownField(def, "color"),
+			Title:    def.Title,
+			Label:    def.Label,
+			Fold:     def.Fold,
+			GFM:      def.GFMKind,
+			Comments: blocks,
+		}
+		if e.alias {
+			entry.Alias = e.target
+		} else {
+			entry.Icon = e.icon
+		}
+		doc.Kinds = append(doc.Kinds, entry)
+	})
+	return doc
+}
+
+// WriteJSON writes the JSON form of the icon set (see Document), indented for reading.
+func (s *IconSet) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s.Document())
+}
+
+// ParseIconSetJSON reads the JSON form of an icon set written by WriteJSON. Kinds are checked
+// like the lines of an icon file and reported in the diagnostics with their 1-based index in
+// the kinds array as the line. An error is returned when the data is not valid JSON or has an
+// unknown schema version.
+func ParseIconSetJSON(data []byte, file string) (*IconSet, []Diagnostic, error) {
+	var doc IconSetDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, nil, err
+	}
+	if doc.Version != IconSetSchemaVersion {
+		return nil, nil, fmt.Errorf("unsupported icon set schema version %d", doc.Version)
+	}
+
+	set := &IconSet{}
+	var diagnostics []Diagnostic
+	line := 0 // Comments get consecutive lines within a block, so Groups keeps the blocks apart
+	addComments := func(blocks [][]string) {
+		for _, block := range blocks {
+			line++
+			for _, text := range block {
+				line++
+				set.comments = append(set.comments, commentEntry{text: text, before: len(set.entries), pos: Position{File: file, Line: line}})
+			}
+		}
+	}
+
+	for i, entry := range doc.Kinds {
+		addComments(entry.Comments)
+		pos := Position{File: file, Line: i + 1, Column: 1}
+		warn := func(kind string, reason string) {
+			diagnostics = append(diagnostics, Diagnostic{Position: pos, Kind: kind, Severity: SeverityWarning, Reason: reason})
+		}
+
+		kind := strings.ToLower(strings.TrimSpace(entry.Kind))
+		target := strings.ToLower(strings.TrimSpace(entry.Alias))
+		switch {
+		case entry.Icon != "" && entry.Alias != "":
+			warn(kind, "kind skipped: both an icon and an alias are set")
+			continue
+		case entry.Alias != "":
+			if problem := keyProblem(kind); problem != "" {
+				warn(kind, "alias skipped: "+problem)
+				continue
+			}
+			if problem := keyProblem(target); problem != "" {
+				warn(kind, "alias skipped: "+problem)
+				continue
+			}
+			set.entries = append(set.entries, iconEntry{kind: kind, target: target, alias: true, pos: pos, targetColumn: 1})
+		default:
+			if problem := keyProblem(kind); problem != "" {
+				warn(kind, "icon skipped: "+problem)
+				continue
+			}
+			icon := strings.TrimSpace(entry.Icon)
+			set.entries = append(set.entries, iconEntry{kind: kind, icon: icon, pos: pos})
+			if icon == "" {
+				warn(kind, fmt.Sprintf("kind %q has an empty icon", kind))
+			}
+		}
+
+		for _, field := range fieldNames {
+			if value := entryField(entry, field); value != "" {
+				set.fields = append(set.fields, fieldEntry{kind: kind, field: field, value: value, pos: pos})
+			}
+		}
+	}
+	addComments(doc.Comments)
+
+	diagnostics = append(diagnostics, set.build()...)
+	SortDiagnostics(diagnostics)
+	return set, diagnostics, nil
+}
+
+// entryField returns the value of a field of a JSON entry.
+func entryField(entry IconSetEntry, field string) string {
+	switch field {
+	case "color":
+		return entry.Color
+	case "title":
+		return entry.Title
+	case "label":
+		return entry.Label
+	case "fold":
+		return entry.Fold
+	case "gfm":
+		return entry.GFM
+	}
+	return ""
+}
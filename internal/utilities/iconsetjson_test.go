@@ -0,0 +1,136 @@
+package utilities
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIconSetDocument(t *testing.T) {
+	doc := ParseIconSet(introspectTestData).Document()
+
+	expected := []IconSetEntry{
+		{Kind: "note", Icon: "<svg>note</svg>", Title: "Note", Comments: [][]string{{"Test icons", "Header comment"}, {"Core kinds"}}},
+		{Kind: "tip", Icon: "<svg>tip</svg>"},
+		{Kind: "info", Alias: "note", Comments: [][]string{{"Aliases", "(GitHub spellings)"}}},
+		{Kind: "notes", Alias: "info"},
+		{Kind: "warning", Icon: "<svg>warning</svg>", Color: "#9A6700", Comments: [][]string{{"Late additions"}}},
+		{Kind: "hint", Icon: "<svg>hint</svg>"},
+	}
+	if doc.Version != IconSetSchemaVersion {
+		t.Errorf("Expected version %d, got %d", IconSetSchemaVersion, doc.Version)
+	}
+	if !reflect.DeepEqual(doc.Kinds, expected) {
+		t.Errorf("Unexpected kinds:\n%+v", doc.Kinds)
+	}
+	if !reflect.DeepEqual(doc.Comments, [][]string{{"Fields only"}}) {
+		t.Errorf("Unexpected trailing comments: %v", doc.Comments)
+	}
+}
+
+func TestIconSetJSONRoundTrip(t *testing.T) {
+	set := ParseIconSet(introspectTestData)
+	var sb strings.Builder
+	if err := set.WriteJSON(&sb); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(sb.String(), `"icon": "<svg>note</svg>"`) {
+		t.Errorf("Expected markup not to be escaped, got:\n%s", sb.String())
+	}
+
+	again, diagnostics, err := ParseIconSetJSON([]byte(sb.String()), "icons.json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(diagnostics) > 0 {
+		t.Errorf("Expected no diagnostics, got %v", diagnostics)
+	}
+	if !reflect.DeepEqual(again.Document(), set.Document()) {
+		t.Errorf("Expected the same document, got %+v", again.Document())
+	}
+	if !reflect.DeepEqual(again.Groups(), set.Groups()) {
+		t.Errorf("Expected the same groups, got %v", again.Groups())
+	}
+	for _, kind := range set.Kinds() {
+		def, _ := set.Definition(kind)
+		if got, ok := again.Definition(kind); !ok || got != def {
+			t.Errorf("Expected the definition %+v, got %+v", def, got)
+		}
+	}
+}
+
+func TestIconSetJSONToIconsRoundTrip(t *testing.T) {
+	data := `{"version": 1, "kinds": [
+		{"kind": "arrow", "icon": "<svg><text>x->y</text></svg>"},
+		{"kind": "next", "alias": "arrow"}
+	]}`
+	set, diagnostics, err := ParseIconSetJSON([]byte(data), "icons.json")
+	if err != nil || len(diagnostics) > 0 {
+		t.Fatalf("Unexpected error %v or diagnostics %v", err, diagnostics)
+	}
+	var sb strings.Builder
+	if err := set.WriteIcons(&sb); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	again, diagnostics := ParseIconSetDiagnostics(sb.String(), "icons.icons")
+	if len(diagnostics) > 0 {
+		t.Errorf("Expected no diagnostics, got %v", diagnostics)
+	}
+	expected := map[string]string{"arrow": "<svg><text>x->y</text></svg>", "next": "<svg><text>x->y</text></svg>"}
+	if !reflect.DeepEqual(again.Icons(), expected) {
+		t.Errorf("Expected icons %v, got %v from:\n%s", expected, again.Icons(), sb.String())
+	}
+	if !reflect.DeepEqual(again.Aliases(), map[string]string{"next": "arrow"}) {
+		t.Errorf("Expected the alias to be kept, got %v", again.Aliases())
+	}
+}
+
+func TestParseIconSetJSONDiagnostics(t *testing.T) {
+	data := `{"version": 1, "kinds": [
+		{"kind": "Note", "icon": "<svg></svg>", "fold": "sideways"},
+		{"kind": "both", "icon": "<svg></svg>", "alias": "note"},
+		{"kind": "noicon-x", "icon": "<svg></svg>"},
+		{"kind": "info", "alias": "missing"},
+		{"kind": "empty"}
+	]}`
+	set, diagnostics, err := ParseIconSetJSON([]byte(data), "icons.json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var lines []string
+	for _, d := range diagnostics {
+		lines = append(lines, d.String())
+	}
+	expected := []string{
+		`icons.json:1:1: warning: field "fold" skipped: "sideways" is not one of none, open or closed`,
+		`icons.json:2:1: warning: kind skipped: both an icon and an alias are set`,
+		`icons.json:3:1: warning: icon skipped: kind "noicon-x" uses the reserved prefix "noicon-"`,
+	}
+	if !reflect.DeepEqual(lines[:3], expected) || len(lines) != 5 {
+		t.Errorf("Unexpected diagnostics:\n%s", strings.Join(lines, "\n"))
+	}
+	if kinds := set.Kinds(); !reflect.DeepEqual(kinds, []string{"note", "empty"}) {
+		t.Errorf("Unexpected kinds: %v", kinds)
+	}
+}
+
+func TestParseIconSetJSONErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+	}{
+		{name: "Invalid JSON", data: `{"version": 1,`},
+		{name: "Unknown version", data: `{"version": 2, "kinds": []}`},
+		{name: "Missing version", data: `{"kinds": []}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, err := ParseIconSetJSON([]byte(tc.data), ""); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package utilities
+
+import (
+	"io"
+	"strings"
+)
+
+// lineBreakReplacer folds line breaks into spaces, since every definition of an icon file is a
+// single line.
+var lineBreakReplacer = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ")
+
+// fieldNames lists the optional fields in the order they are written.
+var fieldNames = []string{"color", "title", "label", "fold", "gfm"}
+
+// WriteIcons writes the icon set in the .icons format: the primary kinds and aliases in their
+// original order, each alias pointing at its direct target, followed by the fields every kind
+// sets itself. Comments are written where they were read, with a blank line before each block.
+// Definitions that lost to others and fields that were skipped are left out, so reading the
+// output gives the same icon set. Line breaks in icons and field values become spaces.
+func (s *IconSet) WriteIcons(w io.Writer) error {
+	var sb strings.Builder
+	blank := func() {
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+	}
+	writeBlocks := func(blocks [][]string) {
+		for _, block := range blocks {
+			blank()
+			for _, text := range block {
+				sb.WriteString(strings.TrimSpace("# "+lineBreakReplacer.Replace(text)) + "\n")
+			}
+		}
+	}
+
+	trailing := false
+	s.walk(func(blocks [][]string, e *iconEntry) {
+		writeBlocks(blocks)
+		switch {
+		case e == nil:
+			trailing = len(blocks) > 0
+		case e.alias:
+			sb.WriteString(e.kind + "->" + e.target + "\n")
+		default:
+			sb.WriteString(e.kind + "|" + lineBreakReplacer.Replace(e.icon) + "\n")
+		}
+	})
+
+	first := true
+	for _, kind := range s.order {
+		for _, field := range fieldNames {
+			value := ownField(s.definitions[kind], field)
+			if value == "" {
+				continue
+			}
+			if first && !trailing {
+				blank()
+			}
+			first = false
+			sb.WriteString(kind + "." + field + "=" + quoteField(field, value) + "\n")
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// walk calls fn for every winning entry of the icon set in entry order, with the blocks of
+// comment lines written before it (and after any entries that lost), and once more with a nil
+// entry for the blocks after the last entry.
+func (s *IconSet) walk(fn func(blocks [][]string, e *iconEntry)) {
+	var blocks [][]string
+	var previous *commentEntry // Comment on the line before, which continues the block
+	c := 0
+	for i := 0; i <= len(s.entries); i++ {
+		for ; c < len(s.comments) && s.comments[c].before == i; c++ {
+			comment := &s.comments[c]
+			if previous == nil || previous.pos.File != comment.pos.File || previous.pos.Line+1 != comment.pos.Line {
+				blocks = append(blocks, nil)
+			}
+			blocks[len(blocks)-1] = append(blocks[len(blocks)-1], comment.text)
+			previous = comment
+		}
+		if i == len(s.entries) {
+			fn(blocks, nil)
+			return
+		}
+		previous = nil
+		if e := &s.entries[i]; s.sources[e.kind] == i && s.definitions[e.kind] != nil {
+			fn(blocks, e)
+			blocks = nil
+		}
+	}
+}
+
+// ownField returns the value of a field the definition sets itself, without inherited values.
+func ownField(def *IconDefinition, field string) string {
+	switch field {
+	case "color":
+		if def.Color != "" {
+			return "#" + def.Color
+		}
+	case "title":
+		return def.Title
+	case "label":
+		return def.Label
+	case "fold":
+		return def.Fold
+	case "gfm":
+		return def.GFMKind
+	}
+	return ""
+}
+
+// quoteField returns the value as written in a field line, in double quotes when reading it
+// back would otherwise trim spaces or remove quotes of the value.
+func quoteField(field string, value string) string {
+	value = lineBreakReplacer.Replace(value)
+	if field != "title" && field != "label" {
+		return value
+	}
+	if value != strings.TrimSpace(value) || unquote(value) != value {
+		return `"` + value + `"`
+	}
+	return value
+}
@@ -0,0 +1,88 @@
+package utilities
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestIconSetWriteIcons(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:  "Comments, aliases and fields",
+			input: introspectTestData,
+			expected: `# Test icons
+# Header comment
+
+# Core kinds
+note|<svg>note</svg>
+tip|<svg>tip</svg>
+
+# Aliases
+# (GitHub spellings)
+info->note
+notes->info
+
+# Late additions
+warning|<svg>warning</svg>
+hint|<svg>hint</svg>
+
+# Fields only
+note.title=Note
+warning.color=#9A6700
+`,
+		},
+		{
+			name:     "Skipped lines are left out",
+			input:    "note|<svg>old</svg>\nbad line\nnote|<svg>new</svg>\nloop->loop\nnote.fold=sideways\nmissing.title=Missing\nnote.fold=OPEN\n",
+			expected: "note|<svg>new</svg>\n\nnote.fold=open\n",
+		},
+		{
+			name:     "Values that need quotes",
+			input:    "note|<svg></svg>\nnote.title=\" Padded \"\nnote.label=\"\"Quoted\"\"\n",
+			expected: "note|<svg></svg>\n\nnote.title=\" Padded \"\nnote.label=\"\"Quoted\"\"\n",
+		},
+		{
+			name:     "Empty icon set",
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var sb strings.Builder
+			if err := ParseIconSet(tc.input).WriteIcons(&sb); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if sb.String() != tc.expected {
+				t.Errorf("Expected:\n%s\nGot:\n%s", tc.expected, sb.String())
+			}
+		})
+	}
+}
+
+func TestIconSetWriteIconsRoundTrip(t *testing.T) {
+	set := ParseIconSet(introspectTestData)
+	var sb strings.Builder
+	if err := set.WriteIcons(&sb); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	again, diagnostics := ParseIconSetDiagnostics(sb.String(), "")
+	if len(diagnostics) > 0 {
+		t.Errorf("Expected no diagnostics, got %v", diagnostics)
+	}
+	if !reflect.DeepEqual(again.Icons(), set.Icons()) || !reflect.DeepEqual(again.Aliases(), set.Aliases()) {
+		t.Errorf("Expected the same icons and aliases, got %v and %v", again.Icons(), again.Aliases())
+	}
+	if !reflect.DeepEqual(again.Groups(), set.Groups()) {
+		t.Errorf("Expected the same groups, got %v", again.Groups())
+	}
+	if !reflect.DeepEqual(again.Colors(), set.Colors()) || !reflect.DeepEqual(again.Titles(), set.Titles()) {
+		t.Errorf("Expected the same fields, got %v and %v", again.Colors(), again.Titles())
+	}
+}
@@ -0,0 +1,86 @@
+package utilities
+
+import (
+	"strings"
+)
+
+// IconGroup is a section of an icon file: the kinds defined after a block of comment lines, up
+// to the next block. A block is a run of comment lines without blank lines between them.
+type IconGroup struct {
+	Comments []string // Comment lines that start the section, without '#' (empty before the first comment)
+	Kinds    []string // Primary kinds and aliases defined in the section, in line order
+}
+
+// Primaries returns the primary kinds of the icon set in file order.
+func (s *IconSet) Primaries() []string {
+	var result []string
+	for _, kind := range s.order {
+		if s.definitions[kind].Primary == "" {
+			result = append(result, kind)
+		}
+	}
+	return result
+}
+
+// AliasesOf returns the aliases that resolve to the primary kind, in file order. It returns nil
+// for aliases and unknown kinds.
+func (s *IconSet) AliasesOf(primary string) []string {
+	primary = strings.ToLower(primary)
+	var result []string
+	for _, kind := range s.order {
+		if s.definitions[kind].Primary == primary {
+			result = append(result, kind)
+		}
+	}
+	return result
+}
+
+// PrimaryOf returns the primary kind an alias resolves to, or kind itself when it is a primary
+// kind. It reports false when the icon set does not define kind.
+func (s *IconSet) PrimaryOf(kind string) (string, bool) {
+	def, ok := s.definitions[strings.ToLower(kind)]
+	if !ok {
+		return "", false
+	}
+	if def.Primary != "" {
+		return def.Primary, true
+	}
+	return def.Kind, true
+}
+
+// Groups returns the sections of the icon file with the kinds each of them defines. A kind
+// belongs to the section of the line that defines it; sections that define no kinds, such as a
+// header comment or a block of fields, are left out. Icon sets combined with MergeIconSets list
+// the sections of each file in turn.
+func (s *IconSet) Groups() []IconGroup {
+	var groups []IconGroup
+	var current IconGroup
+	flush := func() {
+		if len(current.Kinds) > 0 {
+			groups = append(groups, current)
+		}
+		current = IconGroup{}
+	}
+
+	c := 0
+	var previous *commentEntry // Comment on the line before, which continues the block
+	for i := 0; i <= len(s.entries); i++ {
+		for ; c < len(s.comments) && s.comments[c].before == i; c++ {
+			comment := &s.comments[c]
+			if previous == nil || previous.pos.File != comment.pos.File || previous.pos.Line+1 != comment.pos.Line {
+				flush()
+			}
+			current.Comments = append(current.Comments, comment.text)
+			previous = comment
+		}
+		if i == len(s.entries) {
+			break
+		}
+		previous = nil
+		if e := s.entries[i]; s.sources[e.kind] == i && s.definitions[e.kind] != nil {
+			current.Kinds = append(current.Kinds, e.kind)
+		}
+	}
+	flush()
+	return groups
+}
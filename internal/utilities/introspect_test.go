@@ -0,0 +1,91 @@
+package utilities
+
+import (
+	"reflect"
+	"testing"
+)
+
+const introspectTestData = `# Test icons
+# Header comment
+
+# Core kinds
+note|<svg>note</svg>
+tip|<svg>tip</svg>
+
+# Aliases
+#   (GitHub spellings)
+hint->tip
+info->note
+notes->info
+note.title=Note
+
+# Late additions
+warning|<svg>warning</svg>
+hint|<svg>hint</svg>
+# Fields only
+warning.color=#9A6700
+`
+
+func TestIconSetIntrospection(t *testing.T) {
+	set := ParseIconSet(introspectTestData)
+
+	if primaries := set.Primaries(); !reflect.DeepEqual(primaries, []string{"note", "tip", "warning", "hint"}) {
+		t.Errorf("Unexpected primaries: %v", primaries)
+	}
+
+	aliases := map[string][]string{"note": {"info", "notes"}, "tip": nil, "info": nil, "missing": nil}
+	for kind, expected := range aliases {
+		if got := set.AliasesOf(kind); !reflect.DeepEqual(got, expected) {
+			t.Errorf("AliasesOf(%q): expected %v, got %v", kind, expected, got)
+		}
+	}
+	if got := set.AliasesOf("NOTE"); len(got) != 2 {
+		t.Errorf("Expected AliasesOf to ignore case, got %v", got)
+	}
+
+	primaryOf := map[string]string{"notes": "note", "info": "note", "note": "note", "hint": "hint"}
+	for kind, expected := range primaryOf {
+		if got, ok := set.PrimaryOf(kind); !ok || got != expected {
+			t.Errorf("PrimaryOf(%q): expected %q, got %q", kind, expected, got)
+		}
+	}
+	if _, ok := set.PrimaryOf("missing"); ok {
+		t.Error("Expected PrimaryOf to report unknown kinds")
+	}
+
+	expected := []IconGroup{
+		{Comments: []string{"Core kinds"}, Kinds: []string{"note", "tip"}},
+		{Comments: []string{"Aliases", "(GitHub spellings)"}, Kinds: []string{"info", "notes"}},
+		{Comments: []string{"Late additions"}, Kinds: []string{"warning", "hint"}},
+	}
+	if groups := set.Groups(); !reflect.DeepEqual(groups, expected) {
+		t.Errorf("Expected groups %+v, got %+v", expected, groups)
+	}
+}
+
+func TestIconSetGroupsWithoutComments(t *testing.T) {
+	set := ParseIconSet("note|<svg/>\ninfo->note\n")
+	expected := []IconGroup{{Kinds: []string{"note", "info"}}}
+	if groups := set.Groups(); !reflect.DeepEqual(groups, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, groups)
+	}
+}
+
+func TestMergedIconSetGroups(t *testing.T) {
+	base := ParseIconSet("# Base\nnote|<svg/>\ntip|<svg/>\n")
+	brand := ParseIconSet("# Brand\ntip|<svg>brand</svg>\nrocket|<svg/>\n")
+	merged, _ := MergeIconSets(base, brand)
+
+	expected := []IconGroup{
+		{Comments: []string{"Base"}, Kinds: []string{"note"}},
+		{Comments: []string{"Brand"}, Kinds: []string{"tip", "rocket"}},
+	}
+	if groups := merged.Groups(); !reflect.DeepEqual(groups, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, groups)
+	}
+
+	sanitized, _ := merged.Sanitized(DefaultSVGPolicy())
+	if groups := sanitized.Groups(); !reflect.DeepEqual(groups, expected) {
+		t.Errorf("Expected sanitizing to keep the groups, got %+v", groups)
+	}
+}
@@ -0,0 +1,158 @@
+package utilities
+
+import (
+	"slices"
+	"strings"
+)
+
+// KindDefinition describes a single alert kind independently of any icon map.
+type KindDefinition struct {
+	Name    string   // Kind as written in the markdown (matched case-insensitively)
+	Aliases []string // Other names for the kind
+	Icon    string   // Icon markup; empty to inherit the icon of the parent kind, if any
+	Title   string   // Default display title, used when the markdown gives none
+	Denied  bool     // Whether the parser rejects the kind, which then renders as a plain blockquote
+	Extends string   // Parent kind: callouts also get its CSS class and, without an icon of their own, its icon
+}
+
+// KindRegistry holds kind definitions in the order they were registered.
+type KindRegistry struct {
+	definitions map[string]*KindDefinition
+	aliases     map[string]string
+	order       []string
+}
+
+// NewKindRegistry returns a registry holding the given definitions.
+func NewKindRegistry(defs ...KindDefinition) *KindRegistry {
+	r := &KindRegistry{
+		definitions: make(map[string]*KindDefinition),
+		aliases:     make(map[string]string),
+	}
+	for _, def := range defs {
+		r.Register(def)
+	}
+	return r
+}
+
+// KindRegistryFromIconSet returns a registry with a kind for every primary kind of set,
+// carrying its aliases, icon and default title.
+func KindRegistryFromIconSet(set *IconSet) *KindRegistry {
+	r := NewKindRegistry()
+	for _, kind := range set.Kinds() {
+		def, _ := set.Definition(kind)
+		if def.Primary != "" {
+			continue
+		}
+		r.Register(KindDefinition{Name: kind, Icon: def.Icon, Title: def.Title})
+	}
+	for kind, primary := range set.Aliases() {
+		if def, ok := r.definitions[primary]; ok {
+			def.Aliases = append(def.Aliases, kind)
+			r.aliases[kind] = primary
+		}
+	}
+	for _, def := range r.definitions {
+		slices.Sort(def.Aliases)
+	}
+	return r
+}
+
+// Register adds def to the registry, replacing an existing definition with the same name.
+// Names and aliases are lower-cased; an alias that names a registered kind is ignored.
+func (r *KindRegistry) Register(def KindDefinition) {
+	def.Name = strings.ToLower(def.Name)
+	def.Extends = strings.ToLower(def.Extends)
+	if def.Name == "" {
+		return
+	}
+	if old, ok := r.definitions[def.Name]; ok {
+		for _, alias := range old.Aliases {
+			delete(r.aliases, alias)
+		}
+	} else {
+		r.order = append(r.order, def.Name)
+	}
+	r.removeAlias(def.Name)
+
+	aliases := make([]string, 0, len(def.Aliases))
+	for _, alias := range def.Aliases {
+		alias = strings.ToLower(alias)
+		if _, ok := r.definitions[alias]; ok || alias == def.Name || slices.Contains(aliases, alias) {
+			continue
+		}
+		r.removeAlias(alias)
+		r.aliases[alias] = def.Name
+		aliases = append(aliases, alias)
+	}
+	def.Aliases = aliases
+	r.definitions[def.Name] = &def
+}
+
+// removeAlias takes alias away from the kind that currently owns it, so a later definition wins.
+func (r *KindRegistry) removeAlias(alias string) {
+	owner, ok := r.aliases[alias]
+	if !ok {
+		return
+	}
+	if def, ok := r.definitions[owner]; ok {
+		def.Aliases = slices.DeleteFunc(def.Aliases, func(a string) bool { return a == alias })
+	}
+	delete(r.aliases, alias)
+}
+
+// Kinds returns the names of the registered kinds in registration order, without aliases.
+func (r *KindRegistry) Kinds() []string {
+	return slices.Clone(r.order)
+}
+
+// Primary returns the name of the kind that kind (a name or an alias) belongs to,
+// or an empty string when it is not registered.
+func (r *KindRegistry) Primary(kind string) string {
+	kind = strings.ToLower(kind)
+	if _, ok := r.definitions[kind]; ok {
+		return kind
+	}
+	return r.aliases[kind]
+}
+
+// Definition returns the definition of kind, which may be a name or an alias.
+func (r *KindRegistry) Definition(kind string) (KindDefinition, bool) {
+	def, ok := r.definitions[r.Primary(kind)]
+	if !ok {
+		return KindDefinition{}, false
+	}
+	result := *def
+	result.Aliases = slices.Clone(def.Aliases)
+	return result, true
+}
+
+// Allowed reports whether kind is registered and not denied.
+func (r *KindRegistry) Allowed(kind string) bool {
+	def, ok := r.definitions[r.Primary(kind)]
+	return ok && !def.Denied
+}
+
+// Parents returns the chain of kinds that kind extends, nearest first.
+// A cycle in the chain ends it at the first repeated kind.
+func (r *KindRegistry) Parents(kind string) []string {
+	var parents []string
+	current := r.Primary(kind)
+	seen := []string{current}
+	for {
+		def, ok := r.definitions[current]
+		if !ok || def.Extends == "" {
+			break
+		}
+		parent := def.Extends
+		if p := r.Primary(parent); p != "" {
+			parent = p
+		}
+		if slices.Contains(seen, parent) {
+			break
+		}
+		seen = append(seen, parent)
+		parents = append(parents, parent)
+		current = parent
+	}
+	return parents
+}
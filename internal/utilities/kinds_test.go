@@ -0,0 +1,81 @@
+package utilities
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKindRegistry(t *testing.T) {
+	r := NewKindRegistry(
+		KindDefinition{Name: "Security", Aliases: []string{"Vuln", "cve"}, Title: "Security", Extends: "warning"},
+		KindDefinition{Name: "warning", Icon: "<svg>w</svg>", Extends: "caution"},
+		KindDefinition{Name: "draft", Denied: true},
+	)
+
+	t.Run("Kinds keep registration order", func(t *testing.T) {
+		expected := []string{"security", "warning", "draft"}
+		if !reflect.DeepEqual(r.Kinds(), expected) {
+			t.Errorf("Expected %v, got %v", expected, r.Kinds())
+		}
+	})
+
+	t.Run("Aliases resolve to their kind", func(t *testing.T) {
+		def, ok := r.Definition("VULN")
+		if !ok || def.Name != "security" || !reflect.DeepEqual(def.Aliases, []string{"vuln", "cve"}) {
+			t.Errorf("Unexpected definition: %+v", def)
+		}
+		if r.Primary("cve") != "security" || r.Primary("unknown") != "" {
+			t.Errorf("Unexpected primary kinds: %q %q", r.Primary("cve"), r.Primary("unknown"))
+		}
+	})
+
+	t.Run("Allowed flag", func(t *testing.T) {
+		if !r.Allowed("cve") || r.Allowed("draft") || r.Allowed("unknown") {
+			t.Error("Unexpected allowed kinds")
+		}
+	})
+
+	t.Run("Parents follow the extends chain", func(t *testing.T) {
+		expected := []string{"warning", "caution"}
+		if !reflect.DeepEqual(r.Parents("vuln"), expected) {
+			t.Errorf("Expected %v, got %v", expected, r.Parents("vuln"))
+		}
+	})
+
+	t.Run("Extends cycles end the chain", func(t *testing.T) {
+		cyclic := NewKindRegistry(
+			KindDefinition{Name: "a", Extends: "b"},
+			KindDefinition{Name: "b", Extends: "a"},
+		)
+		if !reflect.DeepEqual(cyclic.Parents("a"), []string{"b"}) {
+			t.Errorf("Expected [b], got %v", cyclic.Parents("a"))
+		}
+	})
+
+	t.Run("Later definitions take over names and aliases", func(t *testing.T) {
+		r := NewKindRegistry(
+			KindDefinition{Name: "note", Aliases: []string{"info", "hint"}},
+			KindDefinition{Name: "tip", Aliases: []string{"hint"}},
+			KindDefinition{Name: "info"},
+		)
+		note, _ := r.Definition("note")
+		if !reflect.DeepEqual(note.Aliases, []string{}) {
+			t.Errorf("Expected note to lose both aliases, got %v", note.Aliases)
+		}
+		if r.Primary("hint") != "tip" || r.Primary("info") != "info" {
+			t.Errorf("Unexpected primary kinds: %q %q", r.Primary("hint"), r.Primary("info"))
+		}
+	})
+}
+
+func TestKindRegistryFromIconSet(t *testing.T) {
+	r := KindRegistryFromIconSet(ParseIconSet(iconSetTestData))
+
+	if !reflect.DeepEqual(r.Kinds(), []string{"note", "warning"}) {
+		t.Errorf("Expected the primary kinds, got %v", r.Kinds())
+	}
+	def, ok := r.Definition("info")
+	if !ok || def.Name != "note" || def.Icon != "<svg>note</svg>" || def.Title != "Heads up" || def.Denied {
+		t.Errorf("Unexpected definition: %+v", def)
+	}
+}
@@ -0,0 +1,179 @@
+package utilities
+
+import (
+	"encoding/xml"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// SVGProcessor rewrites icons before they are rendered: it sets or removes attributes of the root
+// <svg> element and optionally minifies the markup. End tags that do not match an open element
+// are dropped and elements left open are closed. Icons that are not SVG, such as emoji, or that
+// cannot be read as markup are returned unchanged. Results are cached by icon, so an icon shared
+// by several kinds or icon sets is processed once. A processor is safe for concurrent use and
+// must not be copied after first use.
+type SVGProcessor struct {
+	Attributes       map[string]string // Attributes to set on the root element, replacing existing values
+	RemoveAttributes []string          // Attributes to remove from the root element
+	Minify           bool              // Whether to drop comments, whitespace between tags and default attributes
+
+	mu    sync.Mutex
+	cache map[string]string
+}
+
+// defaultAttributes holds the attributes that minifying drops when they have the given value
+// (or any value when it is empty). None of them is inherited, so dropping them is always safe.
+var defaultAttributes = map[string]string{
+	"version":             "",
+	"baseprofile":         "",
+	"opacity":             "1",
+	"preserveaspectratio": "xMidYMid meet",
+}
+
+// Process returns the processed icon, from the cache when the icon was processed before.
+func (p *SVGProcessor) Process(icon string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if result, ok := p.cache[icon]; ok {
+		return result
+	}
+	if p.cache == nil {
+		p.cache = make(map[string]string)
+	}
+	result := p.process(icon)
+	p.cache[icon] = result
+	return result
+}
+
+func (p *SVGProcessor) process(icon string) string {
+	dec := xml.NewDecoder(strings.NewReader(icon))
+	dec.Strict = false
+	dec.Entity = xml.HTMLEntity
+
+	var sb strings.Builder
+	var open openElements
+	root := true
+	selfClosed := false // Whether the next end tag belongs to an element written as <name/>
+
+	for {
+		tok, err := dec.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return icon
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			name := qualifiedName(t.Name)
+			attrs := t.Attr
+			if root {
+				if !strings.EqualFold(name, "svg") {
+					return icon
+				}
+				attrs = p.rootAttributes(attrs)
+				root = false
+			}
+			sb.WriteString("<" + name)
+			for _, a := range attrs {
+				attr := qualifiedName(a.Name)
+				if p.Minify && isDefaultAttribute(attr, a.Value) {
+					continue
+				}
+				sb.WriteString(" " + attr + `="` + escapeMarkup(a.Value, true) + `"`)
+			}
+			// Keep the form of empty elements as written.
+			selfClosed = strings.HasSuffix(icon[:dec.InputOffset()], "/>")
+			if selfClosed {
+				sb.WriteString("/>")
+			} else {
+				sb.WriteString(">")
+				open = append(open, name)
+			}
+		case xml.EndElement:
+			// Drop end tags that do not close the innermost open element.
+			if selfClosed {
+				selfClosed = false
+			} else if name := qualifiedName(t.Name); open.pop(name) {
+				sb.WriteString("</" + name + ">")
+			}
+		case xml.CharData:
+			text := string(t)
+			if p.Minify {
+				if strings.TrimSpace(text) == "" {
+					continue
+				}
+				text = whitespaceRegex.ReplaceAllString(text, " ")
+			}
+			sb.WriteString(escapeMarkup(text, false))
+		case xml.Comment:
+			if !p.Minify {
+				sb.WriteString("<!--" + string(t) + "-->")
+			}
+		case xml.ProcInst:
+			if !p.Minify {
+				sb.WriteString("<?" + strings.TrimSpace(t.Target+" "+string(t.Inst)) + "?>")
+			}
+		case xml.Directive:
+			if !p.Minify {
+				sb.WriteString("<!" + string(t) + ">")
+			}
+		}
+	}
+	if root {
+		return icon
+	}
+	return sb.String() + open.endTags()
+}
+
+// rootAttributes returns the attributes of the root element with the configured attributes
+// replaced in place, new ones appended in name order and removed ones dropped.
+func (p *SVGProcessor) rootAttributes(attrs []xml.Attr) []xml.Attr {
+	result := make([]xml.Attr, 0, len(attrs)+len(p.Attributes))
+	set := make(map[string]bool)
+	for _, a := range attrs {
+		name := qualifiedName(a.Name)
+		if containsFold(p.RemoveAttributes, name) {
+			continue
+		}
+		for key, value := range p.Attributes {
+			if strings.EqualFold(key, name) {
+				a.Value = value
+				set[key] = true
+			}
+		}
+		result = append(result, a)
+	}
+
+	var added []string
+	for key := range p.Attributes {
+		if !set[key] && !containsFold(p.RemoveAttributes, key) {
+			added = append(added, key)
+		}
+	}
+	slices.Sort(added)
+	for _, key := range added {
+		result = append(result, xml.Attr{Name: xml.Name{Local: key}, Value: p.Attributes[key]})
+	}
+	return result
+}
+
+func isDefaultAttribute(name string, value string) bool {
+	def, ok := defaultAttributes[strings.ToLower(name)]
+	return ok && (def == "" || strings.TrimSpace(value) == def)
+}
+
+// Processed returns a copy of the icon set with every icon passed through processor.
+func (s *IconSet) Processed(processor *SVGProcessor) *IconSet {
+	result := s.clone()
+	for i := range result.entries {
+		if !result.entries[i].alias {
+			result.entries[i].icon = processor.Process(result.entries[i].icon)
+		}
+	}
+	result.build()
+	return result
+}
@@ -0,0 +1,119 @@
+package utilities
+
+import (
+	"testing"
+)
+
+func TestSVGProcessorProcess(t *testing.T) {
+	testCases := []struct {
+		name      string
+		processor *SVGProcessor
+		input     string
+		expected  string
+	}{
+		{
+			name:      "Override and inject root attributes",
+			processor: &SVGProcessor{Attributes: map[string]string{"width": "16", "height": "16", "class": "callout-icon", "aria-hidden": "true"}},
+			input:     `<svg width="24" height='24' viewBox="0 0 24 24"><path d="M1 1"/></svg>`,
+			expected:  `<svg width="16" height="16" viewBox="0 0 24 24" aria-hidden="true" class="callout-icon"><path d="M1 1"/></svg>`,
+		},
+		{
+			name:      "Attribute names match case-insensitively",
+			processor: &SVGProcessor{Attributes: map[string]string{"viewbox": "0 0 16 16"}},
+			input:     `<svg viewBox="0 0 24 24"></svg>`,
+			expected:  `<svg viewBox="0 0 16 16"></svg>`,
+		},
+		{
+			name:      "Remove root attributes",
+			processor: &SVGProcessor{RemoveAttributes: []string{"width", "height"}},
+			input:     `<svg width="24" height="24" viewBox="0 0 24 24"><rect width="4" height="4"/></svg>`,
+			expected:  `<svg viewBox="0 0 24 24"><rect width="4" height="4"/></svg>`,
+		},
+		{
+			name:      "Values are escaped",
+			processor: &SVGProcessor{Attributes: map[string]string{"aria-label": `a "b" & c`}},
+			input:     `<svg></svg>`,
+			expected:  `<svg aria-label="a &quot;b&quot; &amp; c"></svg>`,
+		},
+		{
+			name:      "Comments are kept without minifying",
+			processor: &SVGProcessor{},
+			input:     "<svg>\n  <!-- dot -->\n  <circle r=\"1\"/>\n</svg>",
+			expected:  "<svg>\n  <!-- dot -->\n  <circle r=\"1\"/>\n</svg>",
+		},
+		{
+			name:      "Minify",
+			processor: &SVGProcessor{Minify: true},
+			input:     "<?xml version=\"1.0\"?>\n<svg version=\"1.1\" opacity=\"1\" preserveAspectRatio=\"xMidYMid meet\">\n  <!-- dot -->\n  <circle r=\"1\" opacity=\"0.5\"/>\n  <text>a   b</text>\n</svg>\n",
+			expected:  `<svg><circle r="1" opacity="0.5"/><text>a b</text></svg>`,
+		},
+		{
+			name:      "Not SVG",
+			processor: &SVGProcessor{Attributes: map[string]string{"class": "callout-icon"}, Minify: true},
+			input:     `<span class="emoji"> 💡 </span>`,
+			expected:  `<span class="emoji"> 💡 </span>`,
+		},
+		{
+			name:      "Plain text",
+			processor: &SVGProcessor{Attributes: map[string]string{"class": "callout-icon"}},
+			input:     "💡",
+			expected:  "💡",
+		},
+		{
+			name:      "Stray end tags are dropped",
+			processor: &SVGProcessor{},
+			input:     `<svg><path d="M0"/></svg></div></details>`,
+			expected:  `<svg><path d="M0"/></svg>`,
+		},
+		{
+			name:      "Unclosed elements are closed",
+			processor: &SVGProcessor{},
+			input:     `<svg><path d="M0">`,
+			expected:  `<svg><path d="M0"></path></svg>`,
+		},
+		{
+			name:      "Not well-formed",
+			processor: &SVGProcessor{Attributes: map[string]string{"class": "callout-icon"}},
+			input:     `<svg><path d="M1 1`,
+			expected:  `<svg><path d="M1 1`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := tc.processor.Process(tc.input); result != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestSVGProcessorCache(t *testing.T) {
+	processor := &SVGProcessor{Attributes: map[string]string{"class": "callout-icon"}}
+	first := processor.Process(`<svg></svg>`)
+	processor.Attributes["class"] = "changed"
+	if second := processor.Process(`<svg></svg>`); second != first {
+		t.Errorf("Expected the cached result %q, got %q", first, second)
+	}
+	if len(processor.cache) != 1 {
+		t.Errorf("Expected one cached icon, got %d", len(processor.cache))
+	}
+}
+
+func TestIconSetProcessed(t *testing.T) {
+	set := ParseIconSet("note|<svg width=\"24\"></svg>\ninfo->note\nnote.title=Heads up\n")
+	processed := set.Processed(&SVGProcessor{Attributes: map[string]string{"width": "16"}})
+
+	if icon := processed.Icons()["note"]; icon != `<svg width="16"></svg>` {
+		t.Errorf("Expected a processed icon, got %q", icon)
+	}
+	if icon := processed.Icons()["info"]; icon != `<svg width="16"></svg>` {
+		t.Errorf("Expected the alias to share the processed icon, got %q", icon)
+	}
+	if title := processed.Titles()["note"]; title != "Heads up" {
+		t.Errorf("Expected the fields to be kept, got %q", title)
+	}
+	if icon := set.Icons()["note"]; icon != `<svg width="24"></svg>` {
+		t.Errorf("Expected the original icon set to be unchanged, got %q", icon)
+	}
+}
@@ -0,0 +1,271 @@
+package utilities
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/zmtcreative/gm-alert-callouts/internal/constants"
+)
+
+// SVGPolicy lists the elements and attributes the SVG sanitizer keeps. Names are matched
+// case-insensitively and include any namespace prefix (e.g. "xlink:href").
+//
+// Whatever the policy says, event handler attributes (on*) are always removed, href attributes
+// must point inside the document ('#id') and attribute values may not contain 'javascript:' or
+// reference external resources with url(), and styles may not use expression(), @import or bindings.
+type SVGPolicy struct {
+	Elements   []string
+	Attributes []string
+}
+
+// DefaultSVGPolicy returns a policy that keeps the shapes, text, gradients and presentation
+// attributes icons are made of (plus <span> placeholders and inline styles), and drops everything
+// that can run script or load content, such as <script>, <foreignObject>, <style>, <image>, <a>
+// and the animation elements.
+func DefaultSVGPolicy() *SVGPolicy {
+	return &SVGPolicy{
+		Elements: []string{
+			"svg", "g", "path", "circle", "ellipse", "line", "polyline", "polygon", "rect",
+			"text", "tspan", "title", "desc", "defs", "symbol", "use",
+			"lineargradient", "radialgradient", "stop", "clippath", "mask", "pattern", "marker",
+			"span",
+		},
+		Attributes: []string{
+			"xmlns", "xmlns:xlink", "version", "id", "class", "viewbox", "preserveaspectratio",
+			"width", "height", "x", "y", "x1", "y1", "x2", "y2", "cx", "cy", "r", "rx", "ry", "dx", "dy",
+			"d", "points", "pathlength", "transform", "offset",
+			"fill", "fill-opacity", "fill-rule", "clip-rule", "clip-path", "mask", "opacity",
+			"stroke", "stroke-width", "stroke-linecap", "stroke-linejoin", "stroke-miterlimit",
+			"stroke-dasharray", "stroke-dashoffset", "stroke-opacity", "vector-effect",
+			"stop-color", "stop-opacity", "gradientunits", "gradienttransform", "spreadmethod",
+			"patternunits", "patterntransform", "clippathunits", "maskunits",
+			"markerwidth", "markerheight", "refx", "refy", "orient",
+			"font-family", "font-size", "font-weight", "text-anchor", "dominant-baseline",
+			"display", "visibility", "role", "focusable", "aria-hidden", "aria-label", "aria-labelledby",
+			"href", "xlink:href", "style",
+		},
+	}
+}
+
+var (
+	javascriptRegex  = regexp.MustCompile(`(?i)j\s*a\s*v\s*a\s*s\s*c\s*r\s*i\s*p\s*t\s*:`)
+	externalURLRegex = regexp.MustCompile(`(?i)url\(\s*['"]?\s*[^#'"\s)]`)
+	unsafeStyleRegex = regexp.MustCompile(`(?i)expression\s*\(|@import|behavior\s*:|-moz-binding`)
+	urlSchemeRegex   = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]*:`)
+	imageDataRegex   = regexp.MustCompile(`(?i)^data:image/(png|gif|jpeg|webp|avif|svg\+xml)[;,]`)
+	iconClassRegex   = regexp.MustCompile(`^[A-Za-z_-][A-Za-z0-9_-]*(\s+[A-Za-z_-][A-Za-z0-9_-]*)*$`)
+)
+
+// Sanitize returns icon with every element and attribute the policy does not allow removed,
+// together with a description of each removal. Comments, processing instructions and
+// directives are dropped silently. Text that is not well-formed markup is removed entirely.
+// End tags that do not close the innermost open element are removed and elements left open are
+// closed, so an icon can neither close nor swallow the markup around it.
+//
+// Image, icon font and emoji sources are kept as they are when they are safe: images must be
+// http(s) or relative URLs, or data URIs of an image type, and icon fonts a list of plain class
+// names. Emoji are escaped when they are rendered.
+func (p *SVGPolicy) Sanitize(icon string) (string, []string) {
+	if src, ok := strings.CutPrefix(icon, constants.ICON_PREFIX_IMAGE); ok {
+		if problem := imageProblem(strings.TrimSpace(src)); problem != "" {
+			return "", []string{"removed icon: " + problem}
+		}
+		return icon, nil
+	}
+	if strings.HasPrefix(icon, constants.ICON_PREFIX_EMOJI) {
+		return icon, nil
+	}
+	if classes, ok := strings.CutPrefix(icon, constants.ICON_PREFIX_FONT); ok {
+		if !iconClassRegex.MatchString(strings.TrimSpace(classes)) {
+			return "", []string{"removed icon: invalid icon font class list"}
+		}
+		return icon, nil
+	}
+
+	dec := xml.NewDecoder(strings.NewReader(icon))
+	dec.Strict = false
+	dec.Entity = xml.HTMLEntity
+
+	var sb strings.Builder
+	var removed []string
+	var open openElements
+	skip := 0           // Depth inside a removed element
+	selfClosed := false // Whether the next end tag belongs to an element written as <name/>
+
+	for {
+		tok, err := dec.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", append(removed, fmt.Sprintf("removed icon: not well-formed markup (%v)", err))
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if skip > 0 {
+				skip++
+				continue
+			}
+			name := qualifiedName(t.Name)
+			if !containsFold(p.Elements, name) {
+				removed = append(removed, fmt.Sprintf("removed element <%s>", name))
+				skip = 1
+				continue
+			}
+			sb.WriteString("<" + name)
+			for _, a := range t.Attr {
+				attr := qualifiedName(a.Name)
+				if problem := p.attributeProblem(attr, a.Value); problem != "" {
+					removed = append(removed, fmt.Sprintf("removed attribute %s from <%s>: %s", attr, name, problem))
+					continue
+				}
+				sb.WriteString(" " + attr + `="` + escapeMarkup(a.Value, true) + `"`)
+			}
+			// Keep the form of empty elements as written.
+			selfClosed = strings.HasSuffix(icon[:dec.InputOffset()], "/>")
+			if selfClosed {
+				sb.WriteString("/>")
+			} else {
+				sb.WriteString(">")
+				open = append(open, name)
+			}
+		case xml.EndElement:
+			name := qualifiedName(t.Name)
+			if skip > 0 {
+				skip--
+			} else if selfClosed {
+				selfClosed = false
+			} else if open.pop(name) {
+				sb.WriteString("</" + name + ">")
+			} else {
+				removed = append(removed, fmt.Sprintf("removed end tag </%s> that does not close the open element", name))
+			}
+		case xml.CharData:
+			if skip == 0 {
+				sb.WriteString(escapeMarkup(string(t), false))
+			}
+		}
+	}
+	for _, name := range open.names() {
+		removed = append(removed, fmt.Sprintf("closed element <%s> that was left open", name))
+	}
+	sb.WriteString(open.endTags())
+	return sb.String(), removed
+}
+
+// openElements holds the names of the elements written so far that are not closed yet, the
+// innermost last. Only the end tag of the innermost element may be written, so markup that is
+// not well-formed cannot close elements around the icon.
+type openElements []string
+
+// pop removes the innermost element and reports true when it is called name.
+func (o *openElements) pop(name string) bool {
+	if n := len(*o); n > 0 && strings.EqualFold((*o)[n-1], name) {
+		*o = (*o)[:n-1]
+		return true
+	}
+	return false
+}
+
+// names returns the names of the open elements, innermost first.
+func (o openElements) names() []string {
+	names := slices.Clone(o)
+	slices.Reverse(names)
+	return names
+}
+
+// endTags returns the end tags that close the open elements.
+func (o openElements) endTags() string {
+	var sb strings.Builder
+	for _, name := range o.names() {
+		sb.WriteString("</" + name + ">")
+	}
+	return sb.String()
+}
+
+// attributeProblem returns why attribute name with the given value is removed, or an empty string.
+func (p *SVGPolicy) attributeProblem(name string, value string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasPrefix(lower, "on"):
+		return "event handler"
+	case !containsFold(p.Attributes, name):
+		return "not allowed"
+	case javascriptRegex.MatchString(value):
+		return "script URL"
+	case (lower == "href" || strings.HasSuffix(lower, ":href")) && !strings.HasPrefix(strings.TrimSpace(value), "#"):
+		return "external reference"
+	case externalURLRegex.MatchString(value):
+		return "external reference"
+	case lower == "style" && unsafeStyleRegex.MatchString(value):
+		return "unsafe style"
+	}
+	return ""
+}
+
+// imageProblem returns why an image source is removed, or an empty string.
+func imageProblem(src string) string {
+	scheme := strings.ToLower(urlSchemeRegex.FindString(src))
+	switch {
+	case src == "":
+		return "empty image URL"
+	case javascriptRegex.MatchString(src):
+		return "script URL"
+	case scheme == "data:":
+		if !imageDataRegex.MatchString(src) {
+			return "data URI that is not an image"
+		}
+	case scheme != "" && scheme != "http:" && scheme != "https:":
+		return "unsupported image URL scheme"
+	}
+	return ""
+}
+
+// qualifiedName returns the name of an element or attribute as written, with its prefix.
+func qualifiedName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+func containsFold(list []string, name string) bool {
+	return slices.ContainsFunc(list, func(s string) bool { return strings.EqualFold(s, name) })
+}
+
+// escapeMarkup escapes the characters that would end text or a double-quoted attribute value.
+func escapeMarkup(s string, attribute bool) string {
+	s = strings.ReplaceAll(s, "&", "&amp;")
+	s = strings.ReplaceAll(s, "<", "&lt;")
+	s = strings.ReplaceAll(s, ">", "&gt;")
+	if attribute {
+		s = strings.ReplaceAll(s, `"`, "&quot;")
+	}
+	return s
+}
+
+// Sanitized returns a copy of the icon set with every icon passed through policy, and a
+// diagnostic for each removal at the line that defines the icon.
+func (s *IconSet) Sanitized(policy *SVGPolicy) (*IconSet, []Diagnostic) {
+	result := s.clone()
+	var diagnostics []Diagnostic
+	for i := range result.entries {
+		e := &result.entries[i]
+		if e.alias {
+			continue
+		}
+		icon, removed := policy.Sanitize(e.icon)
+		e.icon = icon
+		for _, reason := range removed {
+			diagnostics = append(diagnostics, Diagnostic{Position: e.pos, Kind: e.kind, Severity: SeverityWarning,
+				Reason: fmt.Sprintf("icon %q sanitized: %s", e.kind, reason)})
+		}
+	}
+	result.build()
+	return result, diagnostics
+}
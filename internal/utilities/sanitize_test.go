@@ -0,0 +1,173 @@
+package utilities
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSVGPolicySanitize(t *testing.T) {
+	policy := DefaultSVGPolicy()
+
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+		removed  []string
+	}{
+		{
+			name:     "Clean icon",
+			input:    `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24"><path d="M1 1"/><circle r='2'></circle></svg>`,
+			expected: `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24"><path d="M1 1"/><circle r="2"></circle></svg>`,
+		},
+		{
+			name:     "Script and foreignObject",
+			input:    `<svg><script>alert(1)</script><foreignObject><div><b>x</b></div></foreignObject><path d="M1 1"/></svg>`,
+			expected: `<svg><path d="M1 1"/></svg>`,
+			removed:  []string{"removed element <script>", "removed element <foreignObject>"},
+		},
+		{
+			name:     "Event handlers and script URLs",
+			input:    `<svg onload="alert(1)"><use href="javascript:alert(1)"/><use xlink:href="#icon"/><a href="https://example.com"><path d="M1 1"/></a></svg>`,
+			expected: `<svg><use/><use xlink:href="#icon"/></svg>`,
+			removed: []string{
+				"removed attribute onload from <svg>: event handler",
+				"removed attribute href from <use>: script URL",
+				"removed element <a>",
+			},
+		},
+		{
+			name:     "External references",
+			input:    `<svg><rect fill="url(https://evil.example/x)" style="width: expression(alert(1))"/><rect fill="url(#grad)" style="opacity: 0.5"/><use href="other.svg#a"/></svg>`,
+			expected: `<svg><rect/><rect fill="url(#grad)" style="opacity: 0.5"/><use/></svg>`,
+			removed: []string{
+				"removed attribute fill from <rect>: external reference",
+				"removed attribute style from <rect>: unsafe style",
+				"removed attribute href from <use>: external reference",
+			},
+		},
+		{
+			name:     "Comments, prolog and text escaping",
+			input:    `<?xml version="1.0"?><!-- hi --><svg><title>a &amp; b &lt;c&gt;</title></svg>`,
+			expected: `<svg><title>a &amp; b &lt;c&gt;</title></svg>`,
+		},
+		{
+			name:     "Stray end tags",
+			input:    `<svg><path d="M0"/></svg></div></details><p>x`,
+			expected: `<svg><path d="M0"/></svg>`,
+			removed: []string{
+				"removed end tag </div> that does not close the open element",
+				"removed end tag </details> that does not close the open element",
+				"removed element <p>",
+			},
+		},
+		{
+			name:     "Unclosed elements",
+			input:    `<svg><path d="M0">`,
+			expected: `<svg><path d="M0"></path></svg>`,
+			removed:  []string{"closed element <path> that was left open", "closed element <svg> that was left open"},
+		},
+		{
+			name:     "Mismatched end tag",
+			input:    `<svg><g><path d="M0"></g></svg>`,
+			expected: `<svg><g><path d="M0"></path></g></svg>`,
+			removed: []string{
+				"removed end tag </g> that does not close the open element",
+				"removed end tag </svg> that does not close the open element",
+				"closed element <path> that was left open",
+				"closed element <g> that was left open",
+				"closed element <svg> that was left open",
+			},
+		},
+		{
+			name:     "Plain text",
+			input:    "💡",
+			expected: "💡",
+		},
+		{
+			name:     "Image URL",
+			input:    "image:https://cdn.example.com/note.png?a=1&b=2",
+			expected: "image:https://cdn.example.com/note.png?a=1&b=2",
+		},
+		{
+			name:     "Image data URI",
+			input:    "image:data:image/svg+xml;base64,PHN2Zy8+",
+			expected: "image:data:image/svg+xml;base64,PHN2Zy8+",
+		},
+		{
+			name:     "Script image URL",
+			input:    "image:javascript:alert(1)",
+			expected: "",
+			removed:  []string{"removed icon: script URL"},
+		},
+		{
+			name:     "Data URI that is not an image",
+			input:    "image:data:text/html,<script>alert(1)</script>",
+			expected: "",
+			removed:  []string{"removed icon: data URI that is not an image"},
+		},
+		{
+			name:     "Unsupported image URL scheme",
+			input:    "image:file:///etc/passwd",
+			expected: "",
+			removed:  []string{"removed icon: unsupported image URL scheme"},
+		},
+		{
+			name:     "Icon font classes",
+			input:    "font:fa-solid fa-circle-info",
+			expected: "font:fa-solid fa-circle-info",
+		},
+		{
+			name:     "Emoji",
+			input:    "emoji:💡",
+			expected: "emoji:💡",
+		},
+		{
+			name:     "Icon font markup",
+			input:    `font:fa-solid" onclick="alert(1)`,
+			expected: "",
+			removed:  []string{"removed icon: invalid icon font class list"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, removed := policy.Sanitize(tc.input)
+			if got != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, got)
+			}
+			if !reflect.DeepEqual(removed, tc.removed) {
+				t.Errorf("Expected removals %q, got %q", tc.removed, removed)
+			}
+		})
+	}
+
+	t.Run("Malformed markup is removed", func(t *testing.T) {
+		got, removed := policy.Sanitize(`<svg><path d="M1 1"</svg>`)
+		if got != "" || len(removed) != 1 || !strings.HasPrefix(removed[0], "removed icon: not well-formed markup") {
+			t.Errorf("Expected the icon to be removed, got %q %q", got, removed)
+		}
+	})
+
+	t.Run("Custom policy", func(t *testing.T) {
+		got, _ := (&SVGPolicy{Elements: []string{"svg"}, Attributes: []string{"onclick"}}).Sanitize(`<svg onclick="x"><path/></svg>`)
+		if got != "<svg></svg>" {
+			t.Errorf("Expected event handlers to be removed whatever the policy, got %q", got)
+		}
+	})
+}
+
+func TestIconSetSanitized(t *testing.T) {
+	set := ParseIconSet("note|<svg onload=\"x\"><path d=\"M1 1\"/></svg>\ninfo->note\n")
+	clean, diagnostics := set.Sanitized(DefaultSVGPolicy())
+
+	if clean.Icons()["info"] != `<svg><path d="M1 1"/></svg>` {
+		t.Errorf("Expected aliases to get the sanitized icon, got %v", clean.Icons())
+	}
+	if set.Icons()["note"] == clean.Icons()["note"] {
+		t.Error("Expected the original icon set to be left alone")
+	}
+	if len(diagnostics) != 1 || diagnostics[0].String() != `<icons>:1:1: warning: icon "note" sanitized: removed attribute onload from <svg>: event handler` {
+		t.Errorf("Unexpected diagnostics: %v", diagnostics)
+	}
+}
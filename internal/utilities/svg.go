@@ -0,0 +1,27 @@
+package utilities
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	xmlPrologRegex  = regexp.MustCompile(`(?s)<\?xml.*?\?>`)
+	doctypeRegex    = regexp.MustCompile(`(?s)<!DOCTYPE[^\[>]*(\[.*?\])?\s*>`)
+	xmlCommentRegex = regexp.MustCompile(`(?s)<!--.*?-->`)
+	interTagSpace   = regexp.MustCompile(`>\s+<`)
+	whitespaceRegex = regexp.MustCompile(`\s+`)
+)
+
+// CollapseSVG turns the contents of an SVG file into a single line for the .icons format: it
+// strips a byte order mark, the XML prolog, a DOCTYPE and comments, removes the whitespace between
+// tags and collapses any other run of whitespace into a single space.
+func CollapseSVG(svg string) string {
+	svg = strings.TrimPrefix(svg, "\uFEFF")
+	svg = xmlPrologRegex.ReplaceAllString(svg, "")
+	svg = doctypeRegex.ReplaceAllString(svg, "")
+	svg = xmlCommentRegex.ReplaceAllString(svg, "")
+	svg = interTagSpace.ReplaceAllString(svg, "><")
+	svg = whitespaceRegex.ReplaceAllString(svg, " ")
+	return strings.TrimSpace(svg)
+}
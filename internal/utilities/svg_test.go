@@ -0,0 +1,16 @@
+package utilities
+
+import "testing"
+
+func TestCollapseSVG(t *testing.T) {
+	input := "\uFEFF<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n" +
+		"<!DOCTYPE svg PUBLIC \"-//W3C//DTD SVG 1.1//EN\" \"http://www.w3.org/Graphics/SVG/1.1/DTD/svg11.dtd\">\n" +
+		"<!-- Generator: Sketch -->\n" +
+		"<svg xmlns=\"http://www.w3.org/2000/svg\"\n     viewBox=\"0 0 24 24\">\n" +
+		"  <!-- circle -->\n  <circle cx=\"12\" cy=\"12\" r=\"10\"/>\n\t<text>a  b</text>\n</svg>\n"
+
+	expected := `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24"><circle cx="12" cy="12" r="10"/><text>a b</text></svg>`
+	if got := CollapseSVG(input); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
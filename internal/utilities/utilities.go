@@ -1,6 +1,7 @@
 package utilities
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 )
@@ -19,63 +20,36 @@ func FindNamedMatches(regex *regexp.Regexp, str string) map[string]string {
     return results
 }
 
+// iconKeyRegex matches valid kind names, with full Unicode support.
+var iconKeyRegex = regexp.MustCompile(`^\p{L}[\p{L}\p{N}_-]*$`)
+
 // CreateIconsMap creates a map of icon names to their SVG data from the given icon data string.
+// It is a projection of ParseIconSet, which also keeps the optional per-kind fields.
 func CreateIconsMap(icondata string) map[string]string {
-	iconmap := make(map[string]string)
-	wordRegex := regexp.MustCompile(`^\p{L}[\p{L}\p{N}_-]*$`) // update the wordRegex to allow full Unicode support
-
-	// Parse the embedded alert callouts data
-
-	// First pass: load all primary icons.
-	lines := strings.Split(icondata, "\n")
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-
-		// Skip empty lines, comments, and alias definitions.
-		if line == "" || strings.HasPrefix(line, "#") || strings.Contains(line, "->") {
-			continue
-		}
-
-		// Parse core icon definition (key|svg).
-		parts := strings.SplitN(line, "|", 2)
-		if len(parts) == 2 {
-			key := strings.ToLower(strings.TrimSpace(parts[0]))
-			svg := strings.TrimSpace(parts[1])
-
-			// Reserved prefixes, and invalid key patterns are skipped.
-			if strings.HasPrefix(key, "noicon-") || strings.HasPrefix(key, "noicon_") || !wordRegex.MatchString(key) {
-				continue
-			}
-			iconmap[key] = svg
-		}
-	}
-
-	// Second pass: process all aliases.
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-
-		// Skip empty lines, comments, and anything that is not an alias.
-		if line == "" || strings.HasPrefix(line, "#") || !strings.Contains(line, "->") {
-			continue
-		}
-
-		parts := strings.SplitN(line, "->", 2)
-		if len(parts) == 2 {
-			alias := strings.ToLower(strings.TrimSpace(parts[0]))
-			primary := strings.ToLower(strings.TrimSpace(parts[1]))
+	return ParseIconSet(icondata).Icons()
+}
 
-			// Reserved prefixes and invalid patterns are skipped.
-			if strings.HasPrefix(alias, "noicon-") || strings.HasPrefix(alias, "noicon_") || !wordRegex.MatchString(alias) || !wordRegex.MatchString(primary) {
-				continue
-			}
+// CreateIconAliasesMap creates a map of alias names to the primary kind they stand for from the
+// given icon data string. Only aliases that CreateIconsMap would load are included, and an alias
+// of an alias is mapped to the primary kind at the end of the chain.
+func CreateIconAliasesMap(icondata string) map[string]string {
+	return ParseIconSet(icondata).Aliases()
+}
 
-			// If the primary key exists, create the alias.
-			if svg, exists := iconmap[primary]; exists {
-				iconmap[alias] = svg
-			}
-		}
+// keyProblem returns why key cannot be used as a kind or alias, or an empty string when it can.
+func keyProblem(key string) string {
+	switch {
+	case key == "":
+		return "missing kind"
+	case isReservedKey(key):
+		return fmt.Sprintf("kind %q uses the reserved prefix %q", key, key[:7])
+	case !iconKeyRegex.MatchString(key):
+		return fmt.Sprintf("invalid kind %q: kinds start with a letter and contain only letters, numbers, '_' and '-'", key)
 	}
+	return ""
+}
 
-	return iconmap
+// isReservedKey reports whether key starts with the reserved 'noicon-' or 'noicon_' prefix.
+func isReservedKey(key string) bool {
+	return strings.HasPrefix(key, "noicon-") || strings.HasPrefix(key, "noicon_")
 }
@@ -351,3 +351,39 @@ func getKeys(m map[string]string) []string {
 	}
 	return keys
 }
+
+func TestCreateIconAliasesMap(t *testing.T) {
+	iconData := `# Primary icons
+note|<svg>note</svg>
+warning|<svg>warning</svg>
+# Aliases
+info->note
+Information->NOTE
+caution->warning
+attention->caution
+missing->nonexistent
+noicon-info->note
+123bad->note`
+
+	result := CreateIconAliasesMap(iconData)
+
+	expected := map[string]string{
+		"info":        "note",
+		"information": "note",
+		"caution":     "warning",
+		"attention":   "warning", // chains resolve to the primary kind
+	}
+
+	if len(result) != len(expected) {
+		t.Errorf("Expected %d aliases, got %d: %v", len(expected), len(result), result)
+	}
+	for alias, primary := range expected {
+		if result[alias] != primary {
+			t.Errorf("Expected alias '%s' -> '%s', got '%s'", alias, primary, result[alias])
+		}
+	}
+
+	if len(CreateIconAliasesMap("note|<svg>note</svg>")) != 0 {
+		t.Error("Expected no aliases for icon data without alias definitions")
+	}
+}